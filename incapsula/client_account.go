@@ -1,12 +1,17 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 )
 
 // Endpoints (unexported consts)
@@ -45,12 +50,12 @@ type AccountResponse struct {
 		Email         string  `json:"email"`
 		EmailVerified bool    `json:"email_verified"`
 	} `json:"logins"`
-	SupportLevel                 string `json:"support_level"`
-	SupportAllTLSVersions        bool   `json:"supprt_all_tls_versions"`
-	WildcardSANForNewSites       string `json:"wildcard_san_for_new_sites"`
-	NakedDomainSANForNewWWWSites bool   `json:"naked_domain_san_for_new_www_sites"`
-	Res                          int    `json:"res"`
-	ResMessage                   string `json:"res_message"`
+	SupportLevel                 string  `json:"support_level"`
+	SupportAllTLSVersions        bool    `json:"supprt_all_tls_versions"`
+	WildcardSANForNewSites       string  `json:"wildcard_san_for_new_sites"`
+	NakedDomainSANForNewWWWSites bool    `json:"naked_domain_san_for_new_www_sites"`
+	Res                          ResCode `json:"res"`
+	ResMessage                   string  `json:"res_message"`
 	DebugInfo                    struct {
 		IDInfo string `json:"id-info"`
 	} `json:"debug_info"`
@@ -60,23 +65,47 @@ type AccountResponse struct {
 type Client struct {
 	config     *Config
 	httpClient *http.Client
+
+	// Shared by all resources, nil when unlimited
+	rateLimiter  *rateLimiter
+	requestSlots requestSlots
+
+	// Deferred credentials check (offline mode), see checkCredentials
+	credentialsMutex   sync.Mutex
+	credentialsPending bool
+	credentialsErr     error
 }
 
 // NewClient creates a new client with the provided configuration
-func NewClient(config *Config) *Client {
-	client := &http.Client{}
-	return &Client{config: config, httpClient: client}
+func NewClient(config *Config) (*Client, error) {
+	httpClient, err := newHTTPClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &Client{config: config, httpClient: httpClient}
+
+	// The API key must never show up in logs or errors
+	registerSecret(config.APIKey)
+
+	if config.RequestsPerSecond > 0 {
+		client.rateLimiter = newRateLimiter(config.RequestsPerSecond)
+	}
+
+	if config.MaxConcurrentRequests > 0 {
+		client.requestSlots = make(requestSlots, config.MaxConcurrentRequests)
+	}
+
+	return client, nil
 }
 
 // Verify checks the API credentials
-func (c *Client) Verify() (*AccountResponse, error) {
+func (c *Client) Verify(ctx context.Context) (*AccountResponse, error) {
 	log.Println("[INFO] Checking API credentials against Incapsula API")
 
 	// Post form to Incapsula
-	resp, err := c.httpClient.PostForm(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointAccount), url.Values{
-		"api_id":  {c.config.APIID},
-		"api_key": {c.config.APIKey},
-	})
+	// The deferred credentials check relies on Verify, so it must not go through it
+	resp, err := c.sendForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointAccount), url.Values{}, true)
 	if err != nil {
 		return nil, fmt.Errorf("Error checking account: %s", err)
 	}
@@ -97,8 +126,116 @@ func (c *Client) Verify() (*AccountResponse, error) {
 
 	// Look at the response status code from Incapsula
 	if accountResponse.Res != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when checking account: %s", string(responseBody))
+		return nil, newAPIError(resp, responseBody, "Error from Incapsula service when checking account")
 	}
 
 	return &accountResponse, nil
 }
+
+// GetAccount gets the account details, accountID 0 means the provider account (or the account of the API credentials)
+func (c *Client) GetAccount(ctx context.Context, accountID int) (*AccountResponse, error) {
+	log.Printf("[INFO] Getting Incapsula account (account id: %d)\n", c.accountID(accountID))
+
+	values := url.Values{}
+	c.setAccountID(values, accountID)
+
+	// Post form to Incapsula
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointAccount), values, true)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting account (account id: %d): %s", c.accountID(accountID), err)
+	}
+
+	// Read the body
+	defer resp.Body.Close()
+	responseBody, err := ioutil.ReadAll(resp.Body)
+
+	// Dump JSON
+	log.Printf("[DEBUG] Incapsula account JSON response: %s\n", string(responseBody))
+
+	// Parse the JSON
+	var accountResponse AccountResponse
+	err = json.Unmarshal([]byte(responseBody), &accountResponse)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing account JSON response (account id: %d): %s\nresponse: %s", c.accountID(accountID), err, redactSecrets(string(responseBody)))
+	}
+
+	// Look at the response status code from Incapsula
+	if accountResponse.Res != 0 {
+		return nil, newAPIError(resp, responseBody, "Error from Incapsula service when getting account (account id: %d)", c.accountID(accountID))
+	}
+
+	return &accountResponse, nil
+}
+
+// deferCredentialsCheck postpones the credentials check to the first API call (offline mode)
+func (c *Client) deferCredentialsCheck() {
+	c.credentialsMutex.Lock()
+	defer c.credentialsMutex.Unlock()
+
+	c.credentialsPending = true
+}
+
+// checkCredentials runs the deferred credentials check once, before the first API call
+// Missing or rejected credentials fail every subsequent call, transient errors are retried on the next one
+func (c *Client) checkCredentials(ctx context.Context) error {
+	c.credentialsMutex.Lock()
+	defer c.credentialsMutex.Unlock()
+
+	if !c.credentialsPending {
+		return c.credentialsErr
+	}
+
+	log.Println("[INFO] Running deferred API credentials check")
+
+	if strings.TrimSpace(c.config.APIID) == "" {
+		c.credentialsPending, c.credentialsErr = false, errors.New(missingAPIIDMessage)
+		return c.credentialsErr
+	}
+
+	if strings.TrimSpace(c.config.APIKey) == "" {
+		c.credentialsPending, c.credentialsErr = false, errors.New(missingAPIKeyMessage)
+		return c.credentialsErr
+	}
+
+	if c.config.SkipCredentialsValidation {
+		c.credentialsPending = false
+		return nil
+	}
+
+	_, err := c.Verify(ctx)
+	if err == nil || IsAuthError(err) {
+		c.credentialsPending, c.credentialsErr = false, err
+	}
+
+	return err
+}
+
+// accountID returns the account to operate on: the given account, or the provider account_id when 0
+// 0 means the account identified by the authentication parameters
+func (c *Client) accountID(accountID int) int {
+	if accountID != 0 {
+		return accountID
+	}
+	return c.config.AccountID
+}
+
+// setAccountID adds the account_id parameter (APIv1) to the form values when there is an account to operate on
+func (c *Client) setAccountID(values url.Values, accountID int) {
+	if accountID = c.accountID(accountID); accountID != 0 {
+		values.Set("account_id", strconv.Itoa(accountID))
+	}
+}
+
+// withAccountID adds the caid query parameter (APIv2 and API) to the URL when there is an account to operate on
+func (c *Client) withAccountID(rawURL string, accountID int) string {
+	accountID = c.accountID(accountID)
+	if accountID == 0 {
+		return rawURL
+	}
+
+	separator := "?"
+	if strings.Contains(rawURL, "?") {
+		separator = "&"
+	}
+	return fmt.Sprintf("%s%scaid=%d", rawURL, separator, accountID)
+}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -15,7 +16,7 @@ import (
 func TestClientVerifyBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
-	_, err := client.Verify()
+	_, err := client.Verify(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -35,7 +36,7 @@ func TestClientVerifyBadJSON(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	_, err := client.Verify()
+	_, err := client.Verify(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -55,7 +56,7 @@ func TestClientVerifyInvalidAccount(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	_, err := client.Verify()
+	_, err := client.Verify(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -75,8 +76,64 @@ func TestClientVerifyValidAccount(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	_, err := client.Verify()
+	_, err := client.Verify(context.Background())
 	if err != nil {
 		t.Errorf("Should not have received an error, got: %s", err)
 	}
 }
+
+////////////////////////////////////////////////////////////////
+// GetAccount Tests
+////////////////////////////////////////////////////////////////
+
+func TestClientGetAccountInvalidAccount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.String() != fmt.Sprintf("/%s", endpointAccount) {
+			t.Errorf("Should have have hit /%s endpoint. Got: %s", endpointAccount, req.URL.String())
+		}
+		rw.Write([]byte(`{"res":9403,"res_message":"Unknown/unauthorized account_id"}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	accountResponse, err := client.GetAccount(context.Background(), 1234)
+	if err == nil {
+		t.Errorf("Should have received an error")
+	}
+	if !strings.HasPrefix(err.Error(), "Error from Incapsula service when getting account (account id: 1234)") {
+		t.Errorf("Should have received a bad account error, got: %s", err)
+	}
+	if accountResponse != nil {
+		t.Errorf("Should have received a nil accountResponse instance")
+	}
+}
+
+func TestClientGetAccountValidAccount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.String() != fmt.Sprintf("/%s", endpointAccount) {
+			t.Errorf("Should have have hit /%s endpoint. Got: %s", endpointAccount, req.URL.String())
+		}
+		if accountID := req.FormValue("account_id"); accountID != "1234" {
+			t.Errorf("Should have sent the account_id, got: %s", accountID)
+		}
+		rw.Write([]byte(`{"account":{"account_id":1234,"plan_name":"Enterprise","support_level":"Premium","wildcard_san_for_new_sites":"True","naked_domain_san_for_new_www_sites":true,"logins":[{"login_id":42,"email":"admin@example.com","email_verified":true}]},"res":0,"res_message":"OK"}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL, AccountID: 1234}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	accountResponse, err := client.GetAccount(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if accountResponse.Account.AccountID != 1234 || accountResponse.Account.PlanName != "Enterprise" {
+		t.Errorf("Should have parsed the account, got: %+v", accountResponse.Account)
+	}
+	if accountResponse.Account.WildcardSANForNewSites != "True" || !accountResponse.Account.NakedDomainSANForNewWWWSites {
+		t.Errorf("Should have parsed the SAN defaults, got: %+v", accountResponse.Account)
+	}
+	if len(accountResponse.Account.Logins) != 1 || accountResponse.Account.Logins[0].Email != "admin@example.com" {
+		t.Errorf("Should have parsed the logins, got: %+v", accountResponse.Account.Logins)
+	}
+}
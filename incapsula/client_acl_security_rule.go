@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -19,13 +20,11 @@ const blacklistedIPs = "api.acl.blacklisted_ips"
 const whitelistedIPs = "api.acl.whitelisted_ips"
 
 // ConfigureACLSecurityRule adds an ACL rule
-func (c *Client) ConfigureACLSecurityRule(siteID int, ruleID, continents, countries, ips, urls, urlPatterns string) (*SiteStatusResponse, error) {
+func (c *Client) ConfigureACLSecurityRule(ctx context.Context, siteID int, ruleID, continents, countries, ips, urls, urlPatterns string, accountID int) (*SiteStatusResponse, error) {
 	log.Printf("[INFO] Configuring Incapsula ACL rule id: %s for site id: %d\n", ruleID, siteID)
 
 	// Base URL values
 	values := url.Values{
-		"api_id":  {c.config.APIID},
-		"api_key": {c.config.APIKey},
 		"site_id": {strconv.Itoa(siteID)},
 		"rule_id": {ruleID},
 	}
@@ -44,9 +43,10 @@ func (c *Client) ConfigureACLSecurityRule(siteID int, ruleID, continents, countr
 	} else if ruleID == blacklistedIPs || ruleID == whitelistedIPs {
 		values.Add("ips", ips)
 	}
+	c.setAccountID(values, accountID)
 
 	// Post form to Incapsula
-	resp, err := c.httpClient.PostForm(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointACLRuleConfigure), values)
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointACLRuleConfigure), values, true)
 	if err != nil {
 		return nil, fmt.Errorf("Error adding ACL for rule id %s and site id %d", ruleID, siteID)
 	}
@@ -65,20 +65,10 @@ func (c *Client) ConfigureACLSecurityRule(siteID int, ruleID, continents, countr
 		return nil, fmt.Errorf("Error parsing add ACL rule JSON response for rule id %s and site id %d", ruleID, siteID)
 	}
 
-	// Res can sometimes oscillate between a string and number
-	// We need to add safeguards for this inside the provider
-	var resString string
-
-	if resNumber, ok := siteStatusResponse.Res.(float64); ok {
-		resString = fmt.Sprintf("%d", int(resNumber))
-	} else {
-		resString = siteStatusResponse.Res.(string)
-	}
-
 	// Look at the response status code from Incapsula
-	if resString == "0" || resString == "2" {
+	if siteStatusResponse.Res == 0 || siteStatusResponse.Res == 2 {
 		return &siteStatusResponse, nil
 	}
 
-	return nil, fmt.Errorf("Error from Incapsula service when configuring ACL rule for rule id %s and site id %d: %s", ruleID, siteID, string(responseBody))
+	return nil, newAPIError(resp, responseBody, "Error from Incapsula service when configuring ACL rule for rule id %s and site id %d", ruleID, siteID)
 }
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -17,7 +18,7 @@ func TestClientConfigureACLSecurityRuleBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID, ruleID := 42, "42"
-	configureACLSecurityRuleResponse, err := client.ConfigureACLSecurityRule(siteID, ruleID, "", "", "", "", "")
+	configureACLSecurityRuleResponse, err := client.ConfigureACLSecurityRule(context.Background(), siteID, ruleID, "", "", "", "", "", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -41,7 +42,7 @@ func TestClientConfigureACLSecurityRuleBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID, ruleID := 42, "42"
-	configureACLSecurityRuleResponse, err := client.ConfigureACLSecurityRule(siteID, ruleID, "", "", "", "", "")
+	configureACLSecurityRuleResponse, err := client.ConfigureACLSecurityRule(context.Background(), siteID, ruleID, "", "", "", "", "", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -65,7 +66,7 @@ func TestClientConfigureACLSecurityRuleInvalidRule(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID, ruleID := 42, "42"
-	configureACLSecurityRuleResponse, err := client.ConfigureACLSecurityRule(siteID, ruleID, "", "", "", "", "")
+	configureACLSecurityRuleResponse, err := client.ConfigureACLSecurityRule(context.Background(), siteID, ruleID, "", "", "", "", "", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -89,7 +90,7 @@ func TestClientConfigureACLSecurityRuleContinentsValidRule(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID, ruleID := 42, blacklistedCountries
-	configureACLSecurityRuleResponse, err := client.ConfigureACLSecurityRule(siteID, ruleID, "Africa", "Australia", "", "", "")
+	configureACLSecurityRuleResponse, err := client.ConfigureACLSecurityRule(context.Background(), siteID, ruleID, "Africa", "Australia", "", "", "", 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -113,7 +114,7 @@ func TestClientConfigureACLSecurityRuleIPsValidRule(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID, ruleID := 42, blacklistedIPs
-	configureACLSecurityRuleResponse, err := client.ConfigureACLSecurityRule(siteID, ruleID, "", "", "44.55.66.77", "", "")
+	configureACLSecurityRuleResponse, err := client.ConfigureACLSecurityRule(context.Background(), siteID, ruleID, "", "", "44.55.66.77", "", "", 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -137,7 +138,7 @@ func TestClientConfigureACLSecurityRuleURLsValidRule(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID, ruleID := 42, blacklistedURLs
-	configureACLSecurityRuleResponse, err := client.ConfigureACLSecurityRule(siteID, ruleID, "", "", "", "/alpha,/bravo", "CONTAINS,EQUALS")
+	configureACLSecurityRuleResponse, err := client.ConfigureACLSecurityRule(context.Background(), siteID, ruleID, "", "", "", "/alpha,/bravo", "CONTAINS,EQUALS", 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -161,7 +162,7 @@ func TestClientConfigureACLSecurityRuleResultCodeStringValidRule(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID, ruleID := 42, blacklistedCountries
-	configureACLSecurityRuleResponse, err := client.ConfigureACLSecurityRule(siteID, ruleID, "Africa", "Australia", "", "", "")
+	configureACLSecurityRuleResponse, err := client.ConfigureACLSecurityRule(context.Background(), siteID, ruleID, "Africa", "Australia", "", "", "", 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -172,3 +173,21 @@ func TestClientConfigureACLSecurityRuleResultCodeStringValidRule(t *testing.T) {
 		t.Errorf("Site ID doesn't match")
 	}
 }
+
+func TestClientConfigureACLSecurityRuleAccountID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if accountID := req.FormValue("account_id"); accountID != "5678" {
+			t.Errorf("Should have sent the account_id override, got: %s", accountID)
+		}
+		rw.Write([]byte(`{"site_id":123,"res":"0"}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL, AccountID: 1234}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	siteID, ruleID := 42, blacklistedIPs
+	_, err := client.ConfigureACLSecurityRule(context.Background(), siteID, ruleID, "", "", "1.2.3.4", "", "", 5678)
+	if err != nil {
+		t.Errorf("Should not have received an error")
+	}
+}
@@ -0,0 +1,143 @@
+package incapsula
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const endpointAdvancedCachingRules = "sites/performance/caching-rules"
+
+// AdvancedCachingRule is an always cache or never cache resource rule of a site
+// TTL and TTLUnits are only relevant for the always cache resources
+type AdvancedCachingRule struct {
+	URL      string `json:"url"`
+	Pattern  string `json:"pattern"`
+	TTL      int    `json:"ttl,omitempty"`
+	TTLUnits string `json:"ttlUnits,omitempty"`
+}
+
+// Number of seconds of the TTL units of the advanced caching rules
+// The API returns the units in the singular or the plural depending on the TTL
+var advancedCachingRuleTTLUnits = map[string]int{
+	"":        1,
+	"sec":     1,
+	"second":  1,
+	"seconds": 1,
+	"min":     60,
+	"minute":  60,
+	"minutes": 60,
+	"hour":    60 * 60,
+	"hours":   60 * 60,
+	"day":     24 * 60 * 60,
+	"days":    24 * 60 * 60,
+	"week":    7 * 24 * 60 * 60,
+	"weeks":   7 * 24 * 60 * 60,
+}
+
+// TTLSeconds returns the TTL of the rule in seconds
+func (r AdvancedCachingRule) TTLSeconds() (int, error) {
+	unitSeconds, ok := advancedCachingRuleTTLUnits[strings.ToLower(r.TTLUnits)]
+	if !ok {
+		return 0, fmt.Errorf("Unknown TTL units (%s) of the advanced caching rule for url %s", r.TTLUnits, r.URL)
+	}
+	return r.TTL * unitSeconds, nil
+}
+
+// parseAdvancedCachingRules decodes the advanced caching rules of the site status
+// (PerformanceConfiguration.AdvancedCachingRules)
+func parseAdvancedCachingRules(resources []interface{}) ([]AdvancedCachingRule, error) {
+	rules := make([]AdvancedCachingRule, 0, len(resources))
+	if len(resources) == 0 {
+		return rules, nil
+	}
+
+	// Re-encode the resources so that they can be decoded into rules
+	data, err := json.Marshal(resources)
+	if err != nil {
+		return nil, fmt.Errorf("Error encoding advanced caching rules: %s", err)
+	}
+
+	err = json.Unmarshal(data, &rules)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing advanced caching rules: %s", string(data))
+	}
+
+	return rules, nil
+}
+
+// UpdateAdvancedCachingRules replaces the always cache and never cache resource rules of the site
+// The TTL of the always cache rules is in seconds
+func (c *Client) UpdateAdvancedCachingRules(ctx context.Context, siteID string, alwaysCacheRules, neverCacheRules []AdvancedCachingRule, accountID int) error {
+	type AdvancedCachingRulesResponse struct {
+		Res        ResCode `json:"res"`
+		ResMessage string  `json:"res_message"`
+	}
+
+	log.Printf("[INFO] Updating Incapsula advanced caching rules (%d always cache, %d never cache) for siteID: %s\n", len(alwaysCacheRules), len(neverCacheRules), siteID)
+
+	values := url.Values{"site_id": {siteID}}
+
+	// Each list of rules is sent as comma separated values, empty lists are cleared
+	if len(alwaysCacheRules) > 0 {
+		urls := make([]string, 0, len(alwaysCacheRules))
+		patterns := make([]string, 0, len(alwaysCacheRules))
+		durations := make([]string, 0, len(alwaysCacheRules))
+		for _, rule := range alwaysCacheRules {
+			urls = append(urls, rule.URL)
+			patterns = append(patterns, rule.Pattern)
+			durations = append(durations, strconv.Itoa(rule.TTL)+"_sec")
+		}
+		values.Set("always_cache_resource_url", strings.Join(urls, ","))
+		values.Set("always_cache_resource_pattern", strings.Join(patterns, ","))
+		values.Set("always_cache_resource_duration", strings.Join(durations, ","))
+	} else {
+		values.Set("clear_always_cache_rules", "true")
+	}
+
+	if len(neverCacheRules) > 0 {
+		urls := make([]string, 0, len(neverCacheRules))
+		patterns := make([]string, 0, len(neverCacheRules))
+		for _, rule := range neverCacheRules {
+			urls = append(urls, rule.URL)
+			patterns = append(patterns, rule.Pattern)
+		}
+		values.Set("never_cache_resource_url", strings.Join(urls, ","))
+		values.Set("never_cache_resource_pattern", strings.Join(patterns, ","))
+	} else {
+		values.Set("clear_never_cache_rules", "true")
+	}
+	c.setAccountID(values, accountID)
+
+	// Post form to Incapsula
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointAdvancedCachingRules), values, true)
+	if err != nil {
+		return fmt.Errorf("Error updating advanced caching rules on site_id: %s: %s", siteID, err)
+	}
+
+	// Read the body
+	defer resp.Body.Close()
+	responseBody, err := ioutil.ReadAll(resp.Body)
+
+	// Dump JSON
+	log.Printf("[DEBUG] Incapsula update advanced caching rules JSON response: %s\n", string(responseBody))
+
+	// Parse the JSON
+	var advancedCachingRulesResponse AdvancedCachingRulesResponse
+	err = json.Unmarshal([]byte(responseBody), &advancedCachingRulesResponse)
+	if err != nil {
+		return fmt.Errorf("Error parsing update advanced caching rules JSON response for siteID %s: %s", siteID, err)
+	}
+
+	// Look at the response status code from Incapsula
+	if advancedCachingRulesResponse.Res != 0 {
+		return newAPIError(resp, responseBody, "Error from Incapsula service when updating advanced caching rules for siteID %s", siteID)
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package incapsula
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////
+// UpdateAdvancedCachingRules Tests
+////////////////////////////////////////////////////////////////
+
+func TestClientUpdateAdvancedCachingRulesBadConnection(t *testing.T) {
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
+	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
+	siteID := "42"
+	err := client.UpdateAdvancedCachingRules(context.Background(), siteID, nil, nil, 0)
+	if err == nil {
+		t.Errorf("Should have received an error")
+	}
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error updating advanced caching rules on site_id: %s", siteID)) {
+		t.Errorf("Should have received an client error, got: %s", err)
+	}
+}
+
+func TestClientUpdateAdvancedCachingRulesBadJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.String() != fmt.Sprintf("/%s", endpointAdvancedCachingRules) {
+			t.Errorf("Should have have hit /%s endpoint. Got: %s", endpointAdvancedCachingRules, req.URL.String())
+		}
+		rw.Write([]byte(`{`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	siteID := "42"
+	err := client.UpdateAdvancedCachingRules(context.Background(), siteID, nil, nil, 0)
+	if err == nil {
+		t.Errorf("Should have received an error")
+	}
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error parsing update advanced caching rules JSON response for siteID %s", siteID)) {
+		t.Errorf("Should have received a JSON parse error, got: %s", err)
+	}
+}
+
+func TestClientUpdateAdvancedCachingRulesInvalidSite(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.String() != fmt.Sprintf("/%s", endpointAdvancedCachingRules) {
+			t.Errorf("Should have have hit /%s endpoint. Got: %s", endpointAdvancedCachingRules, req.URL.String())
+		}
+		rw.Write([]byte(`{"res":9413,"res_message":"Unknown/unauthorized site_id","debug_info":{"id-info":"13008"}}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	siteID := "42"
+	err := client.UpdateAdvancedCachingRules(context.Background(), siteID, nil, nil, 0)
+	if err == nil {
+		t.Errorf("Should have received an error")
+	}
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error from Incapsula service when updating advanced caching rules for siteID %s", siteID)) {
+		t.Errorf("Should have received a bad site error, got: %s", err)
+	}
+	if !IsNotFound(err) {
+		t.Errorf("Should have received a not found error, got: %s", err)
+	}
+}
+
+func TestClientUpdateAdvancedCachingRulesValidSite(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.String() != fmt.Sprintf("/%s", endpointAdvancedCachingRules) {
+			t.Errorf("Should have have hit /%s endpoint. Got: %s", endpointAdvancedCachingRules, req.URL.String())
+		}
+		if req.FormValue("site_id") != "42" ||
+			req.FormValue("always_cache_resource_url") != "/static,.jpg" ||
+			req.FormValue("always_cache_resource_pattern") != "prefix,suffix" ||
+			req.FormValue("always_cache_resource_duration") != "3600_sec,604800_sec" {
+			t.Errorf("Should have sent the always cache rules, got: %s", req.Form.Encode())
+		}
+		if req.FormValue("never_cache_resource_url") != "/admin" || req.FormValue("never_cache_resource_pattern") != "prefix" {
+			t.Errorf("Should have sent the never cache rules, got: %s", req.Form.Encode())
+		}
+		if req.FormValue("clear_always_cache_rules") != "" || req.FormValue("clear_never_cache_rules") != "" {
+			t.Errorf("Should not have cleared the rules, got: %s", req.Form.Encode())
+		}
+		rw.Write([]byte(`{"res":0,"res_message":"OK"}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	err := client.UpdateAdvancedCachingRules(
+		context.Background(),
+		"42",
+		[]AdvancedCachingRule{{URL: "/static", Pattern: "prefix", TTL: 3600}, {URL: ".jpg", Pattern: "suffix", TTL: 604800}},
+		[]AdvancedCachingRule{{URL: "/admin", Pattern: "prefix"}},
+		0,
+	)
+	if err != nil {
+		t.Errorf("Should not have received an error, got: %s", err)
+	}
+}
+
+func TestClientUpdateAdvancedCachingRulesClear(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.FormValue("clear_always_cache_rules") != "true" || req.FormValue("clear_never_cache_rules") != "true" {
+			t.Errorf("Should have cleared the rules, got: %s", req.Form.Encode())
+		}
+		if req.FormValue("always_cache_resource_url") != "" || req.FormValue("never_cache_resource_url") != "" {
+			t.Errorf("Should not have sent any rules, got: %s", req.Form.Encode())
+		}
+		rw.Write([]byte(`{"res":0,"res_message":"OK"}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	err := client.UpdateAdvancedCachingRules(context.Background(), "42", nil, nil, 0)
+	if err != nil {
+		t.Errorf("Should not have received an error, got: %s", err)
+	}
+}
+
+////////////////////////////////////////////////////////////////
+// parseAdvancedCachingRules Tests
+////////////////////////////////////////////////////////////////
+
+func TestParseAdvancedCachingRules(t *testing.T) {
+	var resources []interface{}
+	err := json.Unmarshal([]byte(`[{"pattern":"prefix","url":"/static","ttl":2,"ttlUnits":"HOURS"},{"pattern":"suffix","url":".jpg","ttl":30}]`), &resources)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rules, err := parseAdvancedCachingRules(resources)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if len(rules) != 2 || rules[0].URL != "/static" || rules[0].Pattern != "prefix" {
+		t.Fatalf("Should have parsed the rules, got: %+v", rules)
+	}
+	if ttl, err := rules[0].TTLSeconds(); err != nil || ttl != 7200 {
+		t.Errorf("Should have converted the TTL to seconds, got: %d (%v)", ttl, err)
+	}
+	if ttl, err := rules[1].TTLSeconds(); err != nil || ttl != 30 {
+		t.Errorf("Should have used seconds without TTL units, got: %d (%v)", ttl, err)
+	}
+}
+
+func TestParseAdvancedCachingRulesSingularUnits(t *testing.T) {
+	var resources []interface{}
+	err := json.Unmarshal([]byte(`[{"pattern":"prefix","url":"/a","ttl":1,"ttlUnits":"SECOND"},{"pattern":"prefix","url":"/b","ttl":1,"ttlUnits":"MINUTE"},{"pattern":"prefix","url":"/c","ttl":1,"ttlUnits":"HOUR"},{"pattern":"prefix","url":"/d","ttl":1,"ttlUnits":"DAY"},{"pattern":"prefix","url":"/e","ttl":1,"ttlUnits":"WEEK"}]`), &resources)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rules, err := parseAdvancedCachingRules(resources)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	expected := []int{1, 60, 60 * 60, 24 * 60 * 60, 7 * 24 * 60 * 60}
+	if len(rules) != len(expected) {
+		t.Fatalf("Should have parsed %d rules, got: %+v", len(expected), rules)
+	}
+	for i, rule := range rules {
+		if ttl, err := rule.TTLSeconds(); err != nil || ttl != expected[i] {
+			t.Errorf("Should have converted the TTL of %s (%s) to %d seconds, got: %d (%v)", rule.URL, rule.TTLUnits, expected[i], ttl, err)
+		}
+	}
+}
+
+func TestParseAdvancedCachingRulesUnexpectedShape(t *testing.T) {
+	if _, err := parseAdvancedCachingRules([]interface{}{"/static"}); err == nil {
+		t.Errorf("Should have received an error")
+	}
+	if _, err := (AdvancedCachingRule{URL: "/static", TTL: 1, TTLUnits: "fortnights"}).TTLSeconds(); err == nil {
+		t.Errorf("Should have received an unknown TTL units error")
+	}
+}
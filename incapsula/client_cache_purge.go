@@ -0,0 +1,80 @@
+package incapsula
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"strings"
+)
+
+const endpointCachePurge = "sites/cache/purge"
+
+// PurgeSiteCache purges all the resources of the site from the cache
+func (c *Client) PurgeSiteCache(ctx context.Context, siteID string, accountID int) error {
+	log.Printf("[INFO] Purging Incapsula cache for siteID: %s\n", siteID)
+
+	return c.purgeCache(ctx, siteID, url.Values{"site_id": {siteID}}, "cache", accountID)
+}
+
+// PurgeCacheResources purges the resources matching the pattern from the cache of the site
+// e.g. "images" (contains), "^/images" (starts with), ".jpg$" (ends with) or "^/index.html$" (exact match)
+func (c *Client) PurgeCacheResources(ctx context.Context, siteID, pattern string, accountID int) error {
+	log.Printf("[INFO] Purging Incapsula cache resources matching (%s) for siteID: %s\n", pattern, siteID)
+
+	return c.purgeCache(ctx, siteID, url.Values{
+		"site_id":       {siteID},
+		"purge_pattern": {pattern},
+	}, fmt.Sprintf("cache resources matching (%s)", pattern), accountID)
+}
+
+// PurgeCacheTags purges the resources tagged with any of the tags from the cache of the site
+func (c *Client) PurgeCacheTags(ctx context.Context, siteID string, tags []string, accountID int) error {
+	tagNames := strings.Join(tags, ",")
+
+	log.Printf("[INFO] Purging Incapsula cache tags (%s) for siteID: %s\n", tagNames, siteID)
+
+	return c.purgeCache(ctx, siteID, url.Values{
+		"site_id":   {siteID},
+		"tag_names": {tagNames},
+	}, fmt.Sprintf("cache tags (%s)", tagNames), accountID)
+}
+
+func (c *Client) purgeCache(ctx context.Context, siteID string, values url.Values, description string, accountID int) error {
+	type CachePurgeResponse struct {
+		Res        ResCode `json:"res"`
+		ResMessage string  `json:"res_message"`
+	}
+
+	c.setAccountID(values, accountID)
+
+	// Post form to Incapsula
+	// A purge is safe to retry, purging twice has the same effect as purging once
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointCachePurge), values, true)
+	if err != nil {
+		return fmt.Errorf("Error purging %s on site_id: %s: %s", description, siteID, err)
+	}
+
+	// Read the body
+	defer resp.Body.Close()
+	responseBody, err := ioutil.ReadAll(resp.Body)
+
+	// Dump JSON
+	log.Printf("[DEBUG] Incapsula purge cache JSON response: %s\n", string(responseBody))
+
+	// Parse the JSON
+	var cachePurgeResponse CachePurgeResponse
+	err = json.Unmarshal([]byte(responseBody), &cachePurgeResponse)
+	if err != nil {
+		return fmt.Errorf("Error parsing purge cache JSON response for siteID %s: %s", siteID, err)
+	}
+
+	// Look at the response status code from Incapsula
+	if cachePurgeResponse.Res != 0 {
+		return newAPIError(resp, responseBody, "Error from Incapsula service when purging %s for siteID %s", description, siteID)
+	}
+
+	return nil
+}
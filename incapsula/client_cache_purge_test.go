@@ -0,0 +1,160 @@
+package incapsula
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////
+// PurgeSiteCache Tests
+////////////////////////////////////////////////////////////////
+
+func TestClientPurgeSiteCacheBadConnection(t *testing.T) {
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
+	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
+	err := client.PurgeSiteCache(context.Background(), "42", 0)
+	if err == nil {
+		t.Errorf("Should have received an error")
+	}
+	if !strings.HasPrefix(err.Error(), "Error purging cache on site_id: 42") {
+		t.Errorf("Should have received an client error, got: %s", err)
+	}
+}
+
+func TestClientPurgeSiteCacheBadJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.String() != fmt.Sprintf("/%s", endpointCachePurge) {
+			t.Errorf("Should have have hit /%s endpoint. Got: %s", endpointCachePurge, req.URL.String())
+		}
+		rw.Write([]byte(`{`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	err := client.PurgeSiteCache(context.Background(), "42", 0)
+	if err == nil {
+		t.Errorf("Should have received an error")
+	}
+	if !strings.HasPrefix(err.Error(), "Error parsing purge cache JSON response for siteID 42") {
+		t.Errorf("Should have received a JSON parse error, got: %s", err)
+	}
+}
+
+func TestClientPurgeSiteCacheInvalidSite(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"res":9413,"res_message":"Unknown/unauthorized site_id","debug_info":{"id-info":"13008"}}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	err := client.PurgeSiteCache(context.Background(), "42", 0)
+	if err == nil {
+		t.Errorf("Should have received an error")
+	}
+	if !strings.HasPrefix(err.Error(), "Error from Incapsula service when purging cache for siteID 42") {
+		t.Errorf("Should have received a bad site error, got: %s", err)
+	}
+}
+
+func TestClientPurgeSiteCacheValidSite(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.String() != fmt.Sprintf("/%s", endpointCachePurge) {
+			t.Errorf("Should have have hit /%s endpoint. Got: %s", endpointCachePurge, req.URL.String())
+		}
+		if req.FormValue("site_id") != "42" || req.FormValue("purge_pattern") != "" || req.FormValue("tag_names") != "" {
+			t.Errorf("Should have purged the whole site, got: %s", req.Form.Encode())
+		}
+		rw.Write([]byte(`{"res":0,"res_message":"OK"}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	err := client.PurgeSiteCache(context.Background(), "42", 0)
+	if err != nil {
+		t.Errorf("Should not have received an error, got: %s", err)
+	}
+}
+
+////////////////////////////////////////////////////////////////
+// PurgeCacheResources Tests
+////////////////////////////////////////////////////////////////
+
+func TestClientPurgeCacheResourcesInvalidSite(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"res":9413,"res_message":"Unknown/unauthorized site_id","debug_info":{"id-info":"13008"}}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	err := client.PurgeCacheResources(context.Background(), "42", "^/images", 0)
+	if err == nil {
+		t.Errorf("Should have received an error")
+	}
+	if !strings.HasPrefix(err.Error(), "Error from Incapsula service when purging cache resources matching (^/images) for siteID 42") {
+		t.Errorf("Should have received a bad site error, got: %s", err)
+	}
+}
+
+func TestClientPurgeCacheResourcesValidSite(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.FormValue("site_id") != "42" || req.FormValue("purge_pattern") != "^/images" {
+			t.Errorf("Should have sent the purge pattern, got: %s", req.Form.Encode())
+		}
+		rw.Write([]byte(`{"res":0,"res_message":"OK"}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	err := client.PurgeCacheResources(context.Background(), "42", "^/images", 0)
+	if err != nil {
+		t.Errorf("Should not have received an error, got: %s", err)
+	}
+}
+
+////////////////////////////////////////////////////////////////
+// PurgeCacheTags Tests
+////////////////////////////////////////////////////////////////
+
+func TestClientPurgeCacheTagsInvalidSite(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"res":9413,"res_message":"Unknown/unauthorized site_id","debug_info":{"id-info":"13008"}}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	err := client.PurgeCacheTags(context.Background(), "42", []string{"product-1"}, 0)
+	if err == nil {
+		t.Errorf("Should have received an error")
+	}
+	if !strings.HasPrefix(err.Error(), "Error from Incapsula service when purging cache tags (product-1) for siteID 42") {
+		t.Errorf("Should have received a bad site error, got: %s", err)
+	}
+}
+
+func TestClientPurgeCacheTagsValidSite(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.FormValue("site_id") != "42" || req.FormValue("tag_names") != "product-1,product-2" {
+			t.Errorf("Should have sent the tag names, got: %s", req.Form.Encode())
+		}
+		rw.Write([]byte(`{"res":0,"res_message":"OK"}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	err := client.PurgeCacheTags(context.Background(), "42", []string{"product-1", "product-2"}, 0)
+	if err != nil {
+		t.Errorf("Should not have received an error, got: %s", err)
+	}
+}
@@ -1,7 +1,7 @@
 package incapsula
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -28,7 +28,7 @@ type CacheRuleWithID struct {
 }
 
 // AddCacheRule adds an incap rule to be managed by Incapsula
-func (c *Client) AddCacheRule(siteID string, rule *CacheRule) (*CacheRuleWithID, error) {
+func (c *Client) AddCacheRule(ctx context.Context, siteID string, rule *CacheRule, accountID int) (*CacheRuleWithID, error) {
 	log.Printf("[INFO] Adding Incapsula Cache Rule for Site ID %s\n", siteID)
 
 	ruleJSON, err := json.Marshal(rule)
@@ -40,10 +40,11 @@ func (c *Client) AddCacheRule(siteID string, rule *CacheRule) (*CacheRuleWithID,
 	log.Printf("[DEBUG] Incapsula Add Cache Rule JSON request body: %s\n", string(ruleJSON))
 
 	// Post form to Incapsula
-	resp, err := c.httpClient.Post(
-		fmt.Sprintf("%s/sites/%s/settings/cache/rules?api_id=%s&api_key=%s", c.config.BaseURLRev2, siteID, c.config.APIID, c.config.APIKey),
-		"application/json",
-		bytes.NewReader(ruleJSON))
+	resp, err := c.doJSONRequest(
+		ctx,
+		http.MethodPost,
+		c.withAccountID(fmt.Sprintf("%s/sites/%s/settings/cache/rules", c.config.BaseURLRev2, siteID), accountID),
+		ruleJSON)
 	if err != nil {
 		return nil, fmt.Errorf("Error from Incapsula service when adding Cache Rule for Site ID %s: %s", siteID, err)
 	}
@@ -57,27 +58,27 @@ func (c *Client) AddCacheRule(siteID string, rule *CacheRule) (*CacheRuleWithID,
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when adding Cache Rule for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, "Error status code %d from Incapsula service when adding Cache Rule for Site ID %s", resp.StatusCode, siteID)
 	}
 
 	// Parse the JSON
 	var cacheRuleWithID CacheRuleWithID
 	err = json.Unmarshal([]byte(responseBody), &cacheRuleWithID)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Cache Rule JSON response for Site ID %s: %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Cache Rule JSON response for Site ID %s: %s\nresponse: %s", siteID, err, redactSecrets(string(responseBody)))
 	}
 
 	return &cacheRuleWithID, nil
 }
 
 // ReadCacheRule gets the specific Incap Rule
-func (c *Client) ReadCacheRule(siteID string, ruleID int) (*CacheRuleWithID, int, error) {
+func (c *Client) ReadCacheRule(ctx context.Context, siteID string, ruleID int, accountID int) (*CacheRuleWithID, error) {
 	log.Printf("[INFO] Getting Incapsula Cache Rule %d for Site ID %s\n", ruleID, siteID)
 
 	// Post form to Incapsula
-	resp, err := c.httpClient.Get(fmt.Sprintf("%s/sites/%s/settings/cache/rules/%d?api_id=%s&api_key=%s", c.config.BaseURLRev2, siteID, ruleID, c.config.APIID, c.config.APIKey))
+	resp, err := c.doJSONRequest(ctx, http.MethodGet, c.withAccountID(fmt.Sprintf("%s/sites/%s/settings/cache/rules/%d", c.config.BaseURLRev2, siteID, ruleID), accountID), nil)
 	if err != nil {
-		return nil, 0, fmt.Errorf("Error from Incapsula service when reading Cache Rule %d for Site ID %s: %s", ruleID, siteID, err)
+		return nil, fmt.Errorf("Error from Incapsula service when reading Cache Rule %d for Site ID %s: %s", ruleID, siteID, err)
 	}
 
 	// Read the body
@@ -89,21 +90,21 @@ func (c *Client) ReadCacheRule(siteID string, ruleID int) (*CacheRuleWithID, int
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, resp.StatusCode, fmt.Errorf("Error status code %d from Incapsula service when reading Cache Rule %d for Site ID %s: %s", resp.StatusCode, ruleID, siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, "Error status code %d from Incapsula service when reading Cache Rule %d for Site ID %s", resp.StatusCode, ruleID, siteID)
 	}
 
 	// Parse the JSON
 	var cacheRuleWithID CacheRuleWithID
 	err = json.Unmarshal([]byte(responseBody), &cacheRuleWithID)
 	if err != nil {
-		return nil, resp.StatusCode, fmt.Errorf("Error parsing Cache Rule %d JSON response for Site ID %s: %s\nresponse: %s", ruleID, siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Cache Rule %d JSON response for Site ID %s: %s\nresponse: %s", ruleID, siteID, err, redactSecrets(string(responseBody)))
 	}
 
-	return &cacheRuleWithID, resp.StatusCode, nil
+	return &cacheRuleWithID, nil
 }
 
 // UpdateCacheRule updates the Incapsula Incap Rule
-func (c *Client) UpdateCacheRule(siteID string, ruleID int, rule *CacheRule) error {
+func (c *Client) UpdateCacheRule(ctx context.Context, siteID string, ruleID int, rule *CacheRule, accountID int) error {
 	log.Printf("[INFO] Updating Incapsula Cache Rule %d for Site ID %s\n", ruleID, siteID)
 
 	ruleJSON, err := json.Marshal(rule)
@@ -112,14 +113,11 @@ func (c *Client) UpdateCacheRule(siteID string, ruleID int, rule *CacheRule) err
 	}
 
 	// Put request to Incapsula
-	req, err := http.NewRequest(
+	resp, err := c.doJSONRequest(
+		ctx,
 		http.MethodPut,
-		fmt.Sprintf("%s/sites/%s/settings/cache/rules/%d?api_id=%s&api_key=%s", c.config.BaseURLRev2, siteID, ruleID, c.config.APIID, c.config.APIKey),
-		bytes.NewReader(ruleJSON))
-	if err != nil {
-		return fmt.Errorf("Error preparing HTTP PUT for updating Cache Rule %d for Site ID %s: %s", ruleID, siteID, err)
-	}
-	resp, err := c.httpClient.Do(req)
+		c.withAccountID(fmt.Sprintf("%s/sites/%s/settings/cache/rules/%d", c.config.BaseURLRev2, siteID, ruleID), accountID),
+		ruleJSON)
 	if err != nil {
 		return fmt.Errorf("Error from Incapsula service when updating Cache Rule %d for Site ID %s: %s", ruleID, siteID, err)
 	}
@@ -133,24 +131,24 @@ func (c *Client) UpdateCacheRule(siteID string, ruleID int, rule *CacheRule) err
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from Incapsula service when updating Cache Rule %d for Site ID %s: %s", resp.StatusCode, ruleID, siteID, string(responseBody))
+		return newAPIError(resp, responseBody, "Error status code %d from Incapsula service when updating Cache Rule %d for Site ID %s", resp.StatusCode, ruleID, siteID)
 	}
 
 	// Parse the JSON
 	var cacheRuleWithID CacheRuleWithID
 	err = json.Unmarshal([]byte(responseBody), &cacheRuleWithID)
 	if err != nil {
-		return fmt.Errorf("Error parsing Cache Rule %d JSON response for Site ID %s: %s\nresponse: %s", ruleID, siteID, err, string(responseBody))
+		return fmt.Errorf("Error parsing Cache Rule %d JSON response for Site ID %s: %s\nresponse: %s", ruleID, siteID, err, redactSecrets(string(responseBody)))
 	}
 
 	return nil
 }
 
 // DeleteCacheRule deletes a site currently managed by Incapsula
-func (c *Client) DeleteCacheRule(siteID string, ruleID int) error {
+func (c *Client) DeleteCacheRule(ctx context.Context, siteID string, ruleID int, accountID int) error {
 	type DeleteCacheRuleResponse struct {
-		Res        int    `json:"res"`
-		ResMessage string `json:"res_message"`
+		Res        ResCode `json:"res"`
+		ResMessage string  `json:"res_message"`
 		DebugInfo  struct {
 			RuleID string `json:"rule_id"`
 			IDInfo string `json:"id-info"`
@@ -160,14 +158,11 @@ func (c *Client) DeleteCacheRule(siteID string, ruleID int) error {
 	log.Printf("[INFO] Deleting Incapsula Cache Rule %d for Site ID %s\n", ruleID, siteID)
 
 	// Delete request to Incapsula
-	req, err := http.NewRequest(
+	resp, err := c.doJSONRequest(
+		ctx,
 		http.MethodDelete,
-		fmt.Sprintf("%s/sites/%s/settings/cache/rules/%d?api_id=%s&api_key=%s", c.config.BaseURLRev2, siteID, ruleID, c.config.APIID, c.config.APIKey),
+		c.withAccountID(fmt.Sprintf("%s/sites/%s/settings/cache/rules/%d", c.config.BaseURLRev2, siteID, ruleID), accountID),
 		nil)
-	if err != nil {
-		return fmt.Errorf("Error preparing HTTP DELETE for deleting Cache Rule %d for Site ID %s: %s", ruleID, siteID, err)
-	}
-	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("Error from Incapsula service when deleting Cache Rule %d for Site ID %s: %s", ruleID, siteID, err)
 	}
@@ -182,18 +177,18 @@ func (c *Client) DeleteCacheRule(siteID string, ruleID int) error {
 	// Check the response code
 	// Unfortunately, this API endpoint is not RESTful and we return 200's back for failures (instead of 40X - joy)
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from Incapsula service when deleting Cache Rule %d for Site ID %s: %s", resp.StatusCode, ruleID, siteID, string(responseBody))
+		return newAPIError(resp, responseBody, "Error status code %d from Incapsula service when deleting Cache Rule %d for Site ID %s", resp.StatusCode, ruleID, siteID)
 	}
 
 	// Parse the JSON
 	var deleteCacheRuleResponse DeleteCacheRuleResponse
 	err = json.Unmarshal([]byte(responseBody), &deleteCacheRuleResponse)
 	if err != nil {
-		return fmt.Errorf("Error parsing Delete Cache Rule %d JSON response for Site ID %s: %s\nresponse: %s", ruleID, siteID, err, string(responseBody))
+		return fmt.Errorf("Error parsing Delete Cache Rule %d JSON response for Site ID %s: %s\nresponse: %s", ruleID, siteID, err, redactSecrets(string(responseBody)))
 	}
 
 	if deleteCacheRuleResponse.Res != 0 {
-		return fmt.Errorf("Error deleting Cache Rule %d JSON response for Site ID %s: %s\nresponse: %s", ruleID, siteID, err, string(responseBody))
+		return newAPIError(resp, responseBody, "Error deleting Cache Rule %d JSON response for Site ID %s", ruleID, siteID)
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -25,7 +26,7 @@ func TestClientAddCacheRuleBadConnection(t *testing.T) {
 		Enabled: true,
 	}
 
-	addCacheRuleResponse, err := client.AddCacheRule(siteID, &rule)
+	addCacheRuleResponse, err := client.AddCacheRule(context.Background(), siteID, &rule, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -42,7 +43,7 @@ func TestClientAddCacheRuleBadJSON(t *testing.T) {
 	apiKey := "bar"
 	siteID := "42"
 
-	endpoint := fmt.Sprintf("/sites/%s/settings/cache/rules?api_id=%s&api_key=%s", siteID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/settings/cache/rules", siteID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		if req.URL.String() != endpoint {
@@ -62,7 +63,7 @@ func TestClientAddCacheRuleBadJSON(t *testing.T) {
 		Enabled: true,
 	}
 
-	addCacheRuleResponse, err := client.AddCacheRule(siteID, &rule)
+	addCacheRuleResponse, err := client.AddCacheRule(context.Background(), siteID, &rule, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -79,7 +80,7 @@ func TestClientAddCacheRuleInvalidRule(t *testing.T) {
 	apiKey := "bar"
 	siteID := "42"
 
-	endpoint := fmt.Sprintf("/sites/%s/settings/cache/rules?api_id=%s&api_key=%s", siteID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/settings/cache/rules", siteID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.WriteHeader(406)
@@ -97,7 +98,7 @@ func TestClientAddCacheRuleInvalidRule(t *testing.T) {
 		Name: "myfirstcoolrule",
 	}
 
-	addCacheRuleResponse, err := client.AddCacheRule(siteID, &rule)
+	addCacheRuleResponse, err := client.AddCacheRule(context.Background(), siteID, &rule, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -114,7 +115,7 @@ func TestClientAddCacheRuleValidRule(t *testing.T) {
 	apiKey := "bar"
 	siteID := "42"
 
-	endpoint := fmt.Sprintf("/sites/%s/settings/cache/rules?api_id=%s&api_key=%s", siteID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/settings/cache/rules", siteID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.WriteHeader(200)
@@ -135,7 +136,7 @@ func TestClientAddCacheRuleValidRule(t *testing.T) {
 		Enabled: true,
 	}
 
-	addCacheRuleResponse, err := client.AddCacheRule(siteID, &rule)
+	addCacheRuleResponse, err := client.AddCacheRule(context.Background(), siteID, &rule, 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -157,7 +158,7 @@ func TestClientReadCacheRuleBadConnection(t *testing.T) {
 	siteID := "42"
 	ruleID := 62
 
-	readCacheRuleResponse, _, err := client.ReadCacheRule(siteID, ruleID)
+	readCacheRuleResponse, err := client.ReadCacheRule(context.Background(), siteID, ruleID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -175,7 +176,7 @@ func TestClientReadCacheRuleBadJSON(t *testing.T) {
 	siteID := "42"
 	ruleID := 62
 
-	endpoint := fmt.Sprintf("/sites/%s/settings/cache/rules/%d?api_id=%s&api_key=%s", siteID, ruleID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/settings/cache/rules/%d", siteID, ruleID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		if req.URL.String() != endpoint {
@@ -188,7 +189,7 @@ func TestClientReadCacheRuleBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readCacheRuleResponse, _, err := client.ReadCacheRule(siteID, ruleID)
+	readCacheRuleResponse, err := client.ReadCacheRule(context.Background(), siteID, ruleID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -206,7 +207,7 @@ func TestClientReadCacheRuleInvalidRule(t *testing.T) {
 	siteID := "42"
 	ruleID := 29010333
 
-	endpoint := fmt.Sprintf("/sites/%s/settings/cache/rules/%d?api_id=%s&api_key=%s", siteID, ruleID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/settings/cache/rules/%d", siteID, ruleID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.WriteHeader(404)
@@ -220,15 +221,15 @@ func TestClientReadCacheRuleInvalidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readCacheRuleResponse, statusCode, err := client.ReadCacheRule(siteID, ruleID)
+	readCacheRuleResponse, err := client.ReadCacheRule(context.Background(), siteID, ruleID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
 	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error status code 404 from Incapsula service when reading Cache Rule %d for Site ID %s", ruleID, siteID)) {
 		t.Errorf("Should have received a bad incap rule error, got: %s", err)
 	}
-	if statusCode != 404 {
-		t.Errorf("Should have received a 404 status code")
+	if !IsNotFound(err) {
+		t.Errorf("Should have received a not found error")
 	}
 	if readCacheRuleResponse != nil {
 		t.Errorf("Should have received a nil readCacheRuleResponse instance")
@@ -241,7 +242,7 @@ func TestClientReadCacheRuleValidRule(t *testing.T) {
 	siteID := "42"
 	ruleID := 66772
 
-	endpoint := fmt.Sprintf("/sites/%s/settings/cache/rules/%d?api_id=%s&api_key=%s", siteID, ruleID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/settings/cache/rules/%d", siteID, ruleID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.WriteHeader(200)
@@ -255,16 +256,13 @@ func TestClientReadCacheRuleValidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readCacheRuleResponse, statusCode, err := client.ReadCacheRule(siteID, ruleID)
+	readCacheRuleResponse, err := client.ReadCacheRule(context.Background(), siteID, ruleID, 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
 	if readCacheRuleResponse == nil {
 		t.Errorf("Should not have received a nil readCacheRuleResponse instance")
 	}
-	if statusCode != 200 {
-		t.Errorf("Should not have received a 200 status code")
-	}
 	if readCacheRuleResponse.RuleID == 0 {
 		t.Errorf("Should not have received an empty rule ID")
 	}
@@ -287,7 +285,7 @@ func TestClientUpdateCacheRuleBadConnection(t *testing.T) {
 		Enabled: true,
 	}
 
-	err := client.UpdateCacheRule(siteID, ruleID, &rule)
+	err := client.UpdateCacheRule(context.Background(), siteID, ruleID, &rule, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -309,7 +307,7 @@ func TestClientUpdateCacheRuleBadJSON(t *testing.T) {
 		Enabled: true,
 	}
 
-	endpoint := fmt.Sprintf("/sites/%s/settings/cache/rules/%d?api_id=%s&api_key=%s", siteID, ruleID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/settings/cache/rules/%d", siteID, ruleID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		if req.URL.String() != endpoint {
@@ -322,7 +320,7 @@ func TestClientUpdateCacheRuleBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.UpdateCacheRule(siteID, ruleID, &rule)
+	err := client.UpdateCacheRule(context.Background(), siteID, ruleID, &rule, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -344,7 +342,7 @@ func TestClientUpdateCacheRuleInvalidRule(t *testing.T) {
 		Enabled: true,
 	}
 
-	endpoint := fmt.Sprintf("/sites/%s/settings/cache/rules/%d?api_id=%s&api_key=%s", siteID, ruleID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/settings/cache/rules/%d", siteID, ruleID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.WriteHeader(404)
@@ -358,7 +356,7 @@ func TestClientUpdateCacheRuleInvalidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.UpdateCacheRule(siteID, ruleID, &rule)
+	err := client.UpdateCacheRule(context.Background(), siteID, ruleID, &rule, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -380,7 +378,7 @@ func TestClientUpdateCacheRuleValidRule(t *testing.T) {
 		Enabled: true,
 	}
 
-	endpoint := fmt.Sprintf("/sites/%s/settings/cache/rules/%d?api_id=%s&api_key=%s", siteID, ruleID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/settings/cache/rules/%d", siteID, ruleID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.WriteHeader(200)
@@ -394,7 +392,7 @@ func TestClientUpdateCacheRuleValidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.UpdateCacheRule(siteID, ruleID, &rule)
+	err := client.UpdateCacheRule(context.Background(), siteID, ruleID, &rule, 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -410,7 +408,7 @@ func TestClientDeleteCacheRuleBadConnection(t *testing.T) {
 	siteID := "42"
 	ruleID := 62
 
-	err := client.DeleteCacheRule(siteID, ruleID)
+	err := client.DeleteCacheRule(context.Background(), siteID, ruleID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -425,7 +423,7 @@ func TestClientDeleteCacheRuleInvalidRule(t *testing.T) {
 	siteID := "42"
 	ruleID := 11111
 
-	endpoint := fmt.Sprintf("/sites/%s/settings/cache/rules/%d?api_id=%s&api_key=%s", siteID, ruleID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/settings/cache/rules/%d", siteID, ruleID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.WriteHeader(200)
@@ -439,7 +437,7 @@ func TestClientDeleteCacheRuleInvalidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.DeleteCacheRule(siteID, ruleID)
+	err := client.DeleteCacheRule(context.Background(), siteID, ruleID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -454,7 +452,7 @@ func TestClientDeleteCacheRuleValidRule(t *testing.T) {
 	siteID := "42"
 	ruleID := 290109
 
-	endpoint := fmt.Sprintf("/sites/%s/settings/cache/rules/%d?api_id=%s&api_key=%s", siteID, ruleID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/settings/cache/rules/%d", siteID, ruleID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.WriteHeader(200)
@@ -468,7 +466,27 @@ func TestClientDeleteCacheRuleValidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.DeleteCacheRule(siteID, ruleID)
+	err := client.DeleteCacheRule(context.Background(), siteID, ruleID, 0)
+	if err != nil {
+		t.Errorf("Should not have received an error")
+	}
+}
+
+func TestClientReadCacheRuleAccountID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/sites/42/settings/cache/rules/66772" {
+			t.Errorf("Should have have hit /sites/42/settings/cache/rules/66772 endpoint. Got: %s", req.URL.Path)
+		}
+		if caid := req.URL.Query().Get("caid"); caid != "5678" {
+			t.Errorf("Should have sent the account_id override as caid, got: %s", caid)
+		}
+		rw.Write([]byte(`{"rule_id":66772,"action":"HTTP_CACHE_MAKE_STATIC","enabled":true,"filter":"isMobile == Yes","name":"test","ttl":300}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURLRev2: server.URL, AccountID: 1234}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	_, err := client.ReadCacheRule(context.Background(), "42", 66772, 5678)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
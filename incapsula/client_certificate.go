@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -18,23 +19,23 @@ const endpointCertificateDelete = "sites/customCertificate/remove"
 
 // CertificateAddResponse contains confirmation of successful upload of certificate
 type CertificateAddResponse struct {
-	Res        int    `json:"res"`
-	ResMessage string `json:"res_message"`
+	Res        ResCode `json:"res"`
+	ResMessage string  `json:"res_message"`
 }
 
 // CertificateListResponse contains site object with details of custom certificate
 type CertificateListResponse struct {
-	Res int `json:"res"`
+	Res ResCode `json:"res"`
 }
 
 // CertificateEditResponse contains confirmation of successful upload of certificate
 type CertificateEditResponse struct {
-	Res        int    `json:"res"`
-	ResMessage string `json:"res_message"`
+	Res        ResCode `json:"res"`
+	ResMessage string  `json:"res_message"`
 }
 
 // AddCertificate adds a custom SSL certificate to a site in Incapsula
-func (c *Client) AddCertificate(siteID, certificate, privateKey, passphrase string) (*CertificateAddResponse, error) {
+func (c *Client) AddCertificate(ctx context.Context, siteID, certificate, privateKey, passphrase string, accountID int) (*CertificateAddResponse, error) {
 	certificate = strings.TrimSpace(certificate)
 	_, err := base64.StdEncoding.DecodeString(certificate)
 	if err != nil {
@@ -45,22 +46,24 @@ func (c *Client) AddCertificate(siteID, certificate, privateKey, passphrase stri
 	log.Printf("[INFO] Adding custom certificate for site_id: %s", siteID)
 
 	values := url.Values{
-		"api_id":      {c.config.APIID},
-		"api_key":     {c.config.APIKey},
 		"site_id":     {siteID},
 		"certificate": {certificate},
 	}
 
 	if privateKey != "" {
 		b64PrivateKey := base64.StdEncoding.EncodeToString([]byte(strings.TrimSpace(privateKey)))
+		registerSecret(privateKey)
+		registerSecret(b64PrivateKey)
 		values.Set("private_key", b64PrivateKey)
 	}
 	if passphrase != "" {
+		registerSecret(passphrase)
 		values.Set("passphrase", passphrase)
 	}
+	c.setAccountID(values, accountID)
 
 	// Post to Incapsula
-	resp, err := c.httpClient.PostForm(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointCertificateAdd), values)
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointCertificateAdd), values, true)
 	if err != nil {
 		return nil, fmt.Errorf("Error from Incapsula service when adding custom certificate for site_id %s: %s", siteID, err)
 	}
@@ -76,27 +79,28 @@ func (c *Client) AddCertificate(siteID, certificate, privateKey, passphrase stri
 	var certificateAddResponse CertificateAddResponse
 	err = json.Unmarshal([]byte(responseBody), &certificateAddResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing add custom certificate JSON response for site_id %s: %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing add custom certificate JSON response for site_id %s: %s\nresponse: %s", siteID, err, redactSecrets(string(responseBody)))
 	}
 
 	// Look at the response status code from Incapsula
 	if certificateAddResponse.Res != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when adding custom certificate for site_id %s: %s", siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, "Error from Incapsula service when adding custom certificate for site_id %s", siteID)
 	}
 
 	return &certificateAddResponse, nil
 }
 
 // ListCertificates gets the list of custom certificates for a site
-func (c *Client) ListCertificates(siteID string) (*CertificateListResponse, error) {
+func (c *Client) ListCertificates(ctx context.Context, siteID string, accountID int) (*CertificateListResponse, error) {
 	log.Printf("[INFO] Getting Incapsula site custom certificates (site_id: %s)\n", siteID)
 
-	// Post form to Incapsula
-	resp, err := c.httpClient.PostForm(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointCertificateList), url.Values{
-		"api_id":  {c.config.APIID},
-		"api_key": {c.config.APIKey},
+	values := url.Values{
 		"site_id": {siteID},
-	})
+	}
+	c.setAccountID(values, accountID)
+
+	// Post form to Incapsula
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointCertificateList), values, true)
 	if err != nil {
 		return nil, fmt.Errorf("Error getting custom certificates for site_id %s: %s", siteID, err)
 	}
@@ -112,40 +116,42 @@ func (c *Client) ListCertificates(siteID string) (*CertificateListResponse, erro
 	var certificateListResponse CertificateListResponse
 	err = json.Unmarshal([]byte(responseBody), &certificateListResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing certificates list JSON response for site_id: %s %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing certificates list JSON response for site_id: %s %s\nresponse: %s", siteID, err, redactSecrets(string(responseBody)))
 	}
 
 	// Look at the response status code from Incapsula
 	if certificateListResponse.Res != 0 {
-		return &certificateListResponse, fmt.Errorf("Error from Incapsula service when getting custom certificates list for site_id %s: %s", siteID, string(responseBody))
+		return &certificateListResponse, newAPIError(resp, responseBody, "Error from Incapsula service when getting custom certificates list for site_id %s", siteID)
 	}
 
 	return &certificateListResponse, nil
 }
 
 // EditCertificate updates the custom certifiacte on an Incapsula site
-func (c *Client) EditCertificate(siteID, certificate, privateKey, passphrase string) (*CertificateEditResponse, error) {
+func (c *Client) EditCertificate(ctx context.Context, siteID, certificate, privateKey, passphrase string, accountID int) (*CertificateEditResponse, error) {
 	b64Certificate := base64.StdEncoding.EncodeToString([]byte(strings.TrimSpace(certificate)))
 
 	log.Printf("[INFO] Editing custom certificate for Incapsula site_id: %s\n", siteID)
 
 	values := url.Values{
-		"api_id":      {c.config.APIID},
-		"api_key":     {c.config.APIKey},
 		"site_id":     {siteID},
 		"certificate": {b64Certificate},
 	}
 
 	if privateKey != "" {
 		b64PrivateKey := base64.StdEncoding.EncodeToString([]byte(strings.TrimSpace(privateKey)))
+		registerSecret(privateKey)
+		registerSecret(b64PrivateKey)
 		values.Set("private_key", b64PrivateKey)
 	}
 	if passphrase != "" {
+		registerSecret(passphrase)
 		values.Set("passphrase", passphrase)
 	}
+	c.setAccountID(values, accountID)
 
 	// Post to Incapsula
-	resp, err := c.httpClient.PostForm(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointCertificateEdit), values)
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointCertificateEdit), values, true)
 	if err != nil {
 		return nil, fmt.Errorf("Error editing custom certificate for site_id: %s: %s", siteID, err)
 	}
@@ -166,29 +172,30 @@ func (c *Client) EditCertificate(siteID, certificate, privateKey, passphrase str
 
 	// Look at the response status code from Incapsula
 	if certificateEditResponse.Res != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when editing custom certificarte for site_id %s: %s", siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, "Error from Incapsula service when editing custom certificarte for site_id %s", siteID)
 	}
 
 	return &certificateEditResponse, nil
 }
 
 // DeleteCertificate deletes a custom certificate for a specific site in Incapsula
-func (c *Client) DeleteCertificate(siteID string) error {
+func (c *Client) DeleteCertificate(ctx context.Context, siteID string, accountID int) error {
 	// Specifically shaded this struct, no need to share across funcs or export
 	// We only care about the response code and possibly the message
 	type CertificateDeleteResponse struct {
-		Res        interface{} `json:"res"`
-		ResMessage string      `json:"res_message"`
+		Res        ResCode `json:"res"`
+		ResMessage string  `json:"res_message"`
 	}
 
 	log.Printf("[INFO] Deleting Incapsula custom certificate for site_id: %s\n", siteID)
 
-	// Post form to Incapsula
-	resp, err := c.httpClient.PostForm(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointCertificateDelete), url.Values{
-		"api_id":  {c.config.APIID},
-		"api_key": {c.config.APIKey},
+	values := url.Values{
 		"site_id": {siteID},
-	})
+	}
+	c.setAccountID(values, accountID)
+
+	// Post form to Incapsula
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointCertificateDelete), values, true)
 	if err != nil {
 		return fmt.Errorf("Error deleting custom certificate for site_id: %s %s", siteID, err)
 	}
@@ -207,20 +214,10 @@ func (c *Client) DeleteCertificate(siteID string) error {
 		return fmt.Errorf("Error deleting custom certificate for site_id: %s %s", siteID, err)
 	}
 
-	// Res can sometimes oscillate between a string and number
-	// We need to add safeguards for this inside the provider
-	var resString string
-
-	if resNumber, ok := certificateDeleteResponse.Res.(float64); ok {
-		resString = fmt.Sprintf("%d", int(resNumber))
-	} else {
-		resString = certificateDeleteResponse.Res.(string)
-	}
-
 	// Look at the response status code from Incapsula
-	if resString == "0" {
+	if certificateDeleteResponse.Res == 0 {
 		return nil
 	}
 
-	return fmt.Errorf("Error from Incapsula service when deleting custom certificate for site_id %s %s", siteID, string(responseBody))
+	return newAPIError(resp, responseBody, "Error from Incapsula service when deleting custom certificate for site_id %s", siteID)
 }
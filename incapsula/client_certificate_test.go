@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -20,7 +21,7 @@ func TestClientAddCertificateBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := "1234"
-	addCertificateResponse, err := client.AddCertificate(siteID, "abc", "def", "efg")
+	addCertificateResponse, err := client.AddCertificate(context.Background(), siteID, "abc", "def", "efg", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -46,7 +47,7 @@ func TestClientAddCertificateBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "1234"
-	addCertificateResponse, err := client.AddCertificate(siteID, "", "", "")
+	addCertificateResponse, err := client.AddCertificate(context.Background(), siteID, "", "", "", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -72,7 +73,7 @@ func TestClientAddCertificateInvalidRule(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "1234"
-	addCertificateResponse, err := client.AddCertificate(siteID, "", "", "")
+	addCertificateResponse, err := client.AddCertificate(context.Background(), siteID, "", "", "", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -98,7 +99,7 @@ func TestClientAddCertificateValidRule(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "1234"
-	addCertificateResponse, err := client.AddCertificate(siteID, "", "", "")
+	addCertificateResponse, err := client.AddCertificate(context.Background(), siteID, "", "", "", 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -120,7 +121,7 @@ func TestClientListCertificatesBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := "1234"
-	listCertificatesResponse, err := client.ListCertificates(siteID)
+	listCertificatesResponse, err := client.ListCertificates(context.Background(), siteID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -146,7 +147,7 @@ func TestClientListCertificatesBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "1234"
-	listCertificatesResponse, err := client.ListCertificates(siteID)
+	listCertificatesResponse, err := client.ListCertificates(context.Background(), siteID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -172,7 +173,7 @@ func TestClientListCertificatesInvalidRequest(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "1234"
-	listCertificatesResponse, err := client.ListCertificates(siteID)
+	listCertificatesResponse, err := client.ListCertificates(context.Background(), siteID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -197,7 +198,7 @@ func TestClientEditCertificateBadConnection(t *testing.T) {
 	certificate := "foo"
 	privateKey := "bar"
 	passphrase := "loremipsum"
-	editCertificateResponse, err := client.EditCertificate(siteID, certificate, privateKey, passphrase)
+	editCertificateResponse, err := client.EditCertificate(context.Background(), siteID, certificate, privateKey, passphrase, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -226,7 +227,7 @@ func TestClientEditCertificateBadJSON(t *testing.T) {
 	certificate := "foo"
 	privateKey := "bar"
 	passphrase := "loremipsum"
-	editCertificateResponse, err := client.EditCertificate(siteID, certificate, privateKey, passphrase)
+	editCertificateResponse, err := client.EditCertificate(context.Background(), siteID, certificate, privateKey, passphrase, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -255,7 +256,7 @@ func TestClientEditCertificateValidRule(t *testing.T) {
 	certificate := "foo"
 	privateKey := "bar"
 	passphrase := "loremipsum"
-	editCertificateResponse, err := client.EditCertificate(siteID, certificate, privateKey, passphrase)
+	editCertificateResponse, err := client.EditCertificate(context.Background(), siteID, certificate, privateKey, passphrase, 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -278,7 +279,7 @@ func TestClientDeleteCertificateBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := "1234"
-	err := client.DeleteCertificate(siteID)
+	err := client.DeleteCertificate(context.Background(), siteID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -301,7 +302,7 @@ func TestClientDeleteCertificateBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "1234"
-	err := client.DeleteCertificate(siteID)
+	err := client.DeleteCertificate(context.Background(), siteID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -324,7 +325,7 @@ func TestClientDeleteCertificateInvalidSiteID(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "1234"
-	err := client.DeleteCertificate(siteID)
+	err := client.DeleteCertificate(context.Background(), siteID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -347,7 +348,7 @@ func TestClientDeleteCertificateValidSite(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "1234"
-	err := client.DeleteCertificate(siteID)
+	err := client.DeleteCertificate(context.Background(), siteID, 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -16,13 +17,13 @@ const endpointDataCenterDelete = "sites/dataCenters/delete"
 
 // DataCenterAddResponse contains id of data center
 type DataCenterAddResponse struct {
-	Res          interface{} `json:"res"`
-	DataCenterID string      `json:"datacenter_id"`
+	Res          ResCode `json:"res"`
+	DataCenterID string  `json:"datacenter_id"`
 }
 
 // DataCenterListResponse contains list of data centers and servers
 type DataCenterListResponse struct {
-	Res interface{} `json:"res"`
+	Res ResCode `json:"res"`
 	DCs []struct {
 		ID      string `json:"id"`
 		Enabled string `json:"enabled"`
@@ -40,23 +41,24 @@ type DataCenterListResponse struct {
 
 // DataCenterEditResponse contains edit response message
 type DataCenterEditResponse struct {
-	Res        interface{} `json:"res"`
-	ResMessage string      `json:"res_message"`
+	Res        ResCode `json:"res"`
+	ResMessage string  `json:"res_message"`
 }
 
 // AddDataCenter adds an incap rule to be managed by Incapsula
-func (c *Client) AddDataCenter(siteID, name, serverAddress, isContent string) (*DataCenterAddResponse, error) {
+func (c *Client) AddDataCenter(ctx context.Context, siteID, name, serverAddress, isContent string, accountID int) (*DataCenterAddResponse, error) {
 	log.Printf("[INFO] Adding Incapsula data center for siteID: %s\n", siteID)
 
-	// Post form to Incapsula
-	resp, err := c.httpClient.PostForm(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataCenterAdd), url.Values{
-		"api_id":         {c.config.APIID},
-		"api_key":        {c.config.APIKey},
+	values := url.Values{
 		"site_id":        {siteID},
 		"name":           {name},
 		"server_address": {serverAddress},
 		"is_content":     {isContent},
-	})
+	}
+	c.setAccountID(values, accountID)
+
+	// Post form to Incapsula
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataCenterAdd), values, false)
 	if err != nil {
 		return nil, fmt.Errorf("Error from Incapsula service when adding data center for siteID %s: %s", siteID, err)
 	}
@@ -72,37 +74,28 @@ func (c *Client) AddDataCenter(siteID, name, serverAddress, isContent string) (*
 	var dataCenterAddResponse DataCenterAddResponse
 	err = json.Unmarshal([]byte(responseBody), &dataCenterAddResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing add data center JSON response for siteID %s: %s\nresponse: %s", siteID, err, string(responseBody))
-	}
-
-	// Res can sometimes oscillate between a string and number
-	// We need to add safeguards for this inside the provider
-	var resString string
-
-	if resNumber, ok := dataCenterAddResponse.Res.(float64); ok {
-		resString = fmt.Sprintf("%d", int(resNumber))
-	} else {
-		resString = dataCenterAddResponse.Res.(string)
+		return nil, fmt.Errorf("Error parsing add data center JSON response for siteID %s: %s\nresponse: %s", siteID, err, redactSecrets(string(responseBody)))
 	}
 
 	// Look at the response status code from Incapsula
-	if resString != "0" {
-		return nil, fmt.Errorf("Error from Incapsula service when adding data center for siteID %s: %s", siteID, string(responseBody))
+	if dataCenterAddResponse.Res != 0 {
+		return nil, newAPIError(resp, responseBody, "Error from Incapsula service when adding data center for siteID %s", siteID)
 	}
 
 	return &dataCenterAddResponse, nil
 }
 
 // ListDataCenters gets the Incapsula list of data centers
-func (c *Client) ListDataCenters(siteID string) (*DataCenterListResponse, error) {
+func (c *Client) ListDataCenters(ctx context.Context, siteID string, accountID int) (*DataCenterListResponse, error) {
 	log.Printf("[INFO] Getting Incapsula data centers (site_id: %s)\n", siteID)
 
-	// Post form to Incapsula
-	resp, err := c.httpClient.PostForm(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataCenterList), url.Values{
-		"api_id":  {c.config.APIID},
-		"api_key": {c.config.APIKey},
+	values := url.Values{
 		"site_id": {siteID},
-	})
+	}
+	c.setAccountID(values, accountID)
+
+	// Post form to Incapsula
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataCenterList), values, true)
 	if err != nil {
 		return nil, fmt.Errorf("Error getting data centers for siteID %s: %s", siteID, err)
 	}
@@ -118,35 +111,23 @@ func (c *Client) ListDataCenters(siteID string) (*DataCenterListResponse, error)
 	var dataCenterListResponse DataCenterListResponse
 	err = json.Unmarshal([]byte(responseBody), &dataCenterListResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing data centers list JSON response for siteID: %s %s\nresponse: %s", siteID, err, string(responseBody))
-	}
-
-	// Res can sometimes oscillate between a string and number
-	// We need to add safeguards for this inside the provider
-	var resString string
-
-	if resNumber, ok := dataCenterListResponse.Res.(float64); ok {
-		resString = fmt.Sprintf("%d", int(resNumber))
-	} else {
-		resString = dataCenterListResponse.Res.(string)
+		return nil, fmt.Errorf("Error parsing data centers list JSON response for siteID: %s %s\nresponse: %s", siteID, err, redactSecrets(string(responseBody)))
 	}
 
 	// Look at the response status code from Incapsula
-	if resString != "0" {
-		return &dataCenterListResponse, fmt.Errorf("Error from Incapsula service when getting data centers list (site_id: %s): %s", siteID, string(responseBody))
+	if dataCenterListResponse.Res != 0 {
+		return &dataCenterListResponse, newAPIError(resp, responseBody, "Error from Incapsula service when getting data centers list (site_id: %s)", siteID)
 	}
 
 	return &dataCenterListResponse, nil
 }
 
 // EditDataCenter edits the Incapsula incap rule
-func (c *Client) EditDataCenter(dcID, name, isContent, isEnabled string) (*DataCenterEditResponse, error) {
+func (c *Client) EditDataCenter(ctx context.Context, dcID, name, isContent, isEnabled string, accountID int) (*DataCenterEditResponse, error) {
 	log.Printf("[INFO] Editing Incapsula data center for dcID: %s\n", dcID)
 
 	values := url.Values{
-		"api_id":  {c.config.APIID},
-		"api_key": {c.config.APIKey},
-		"dc_id":   {dcID},
+		"dc_id": {dcID},
 	}
 
 	if name != "" {
@@ -161,8 +142,10 @@ func (c *Client) EditDataCenter(dcID, name, isContent, isEnabled string) (*DataC
 		values.Add("is_enabled", isEnabled)
 	}
 
+	c.setAccountID(values, accountID)
+
 	// Post form to Incapsula
-	resp, err := c.httpClient.PostForm(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataCenterEdit), values)
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataCenterEdit), values, true)
 	if err != nil {
 		return nil, fmt.Errorf("Error editing data center  for dcID: %s: %s", dcID, err)
 	}
@@ -181,41 +164,32 @@ func (c *Client) EditDataCenter(dcID, name, isContent, isEnabled string) (*DataC
 		return nil, fmt.Errorf("Error parsing edit dta center JSON response for dcID %s: %s", dcID, err)
 	}
 
-	// Res can sometimes oscillate between a string and number
-	// We need to add safeguards for this inside the provider
-	var resString string
-
-	if resNumber, ok := dataCenterEditResponse.Res.(float64); ok {
-		resString = fmt.Sprintf("%d", int(resNumber))
-	} else {
-		resString = dataCenterEditResponse.Res.(string)
-	}
-
 	// Look at the response status code from Incapsula
-	if resString != "0" {
-		return nil, fmt.Errorf("Error from Incapsula service when editing data center for dcID %s: %s", dcID, string(responseBody))
+	if dataCenterEditResponse.Res != 0 {
+		return nil, newAPIError(resp, responseBody, "Error from Incapsula service when editing data center for dcID %s", dcID)
 	}
 
 	return &dataCenterEditResponse, nil
 }
 
 // DeleteDataCenter deletes a site currently managed by Incapsula
-func (c *Client) DeleteDataCenter(dcID string) error {
+func (c *Client) DeleteDataCenter(ctx context.Context, dcID string, accountID int) error {
 	// Specifically shaded this struct, no need to share across funcs or export
 	// We only care about the response code and possibly the message
 	type DataCenterDeleteResponse struct {
-		Res        interface{} `json:"res"`
-		ResMessage string      `json:"res_message"`
+		Res        ResCode `json:"res"`
+		ResMessage string  `json:"res_message"`
 	}
 
 	log.Printf("[INFO] Deleting Incapsula data center id: %s\n", dcID)
 
+	values := url.Values{
+		"dc_id": {dcID},
+	}
+	c.setAccountID(values, accountID)
+
 	// Post form to Incapsula
-	resp, err := c.httpClient.PostForm(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataCenterDelete), url.Values{
-		"api_id":  {c.config.APIID},
-		"api_key": {c.config.APIKey},
-		"dc_id":   {dcID},
-	})
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataCenterDelete), values, true)
 	if err != nil {
 		return fmt.Errorf("Error deleting data center (dc_id: %s): %s", dcID, err)
 	}
@@ -234,20 +208,10 @@ func (c *Client) DeleteDataCenter(dcID string) error {
 		return fmt.Errorf("Error parsing delete data center JSON response (dc_id: %s): %s", dcID, err)
 	}
 
-	// Res can sometimes oscillate between a string and number
-	// We need to add safeguards for this inside the provider
-	var resString string
-
-	if resNumber, ok := dataCenterDeleteResponse.Res.(float64); ok {
-		resString = fmt.Sprintf("%d", int(resNumber))
-	} else {
-		resString = dataCenterDeleteResponse.Res.(string)
-	}
-
 	// Look at the response status code from Incapsula
-	if resString == "0" || resString == "2" || resString == "9413" {
+	if dataCenterDeleteResponse.Res == 0 || dataCenterDeleteResponse.Res == 2 || dataCenterDeleteResponse.Res == resCodeUnknownSite {
 		return nil
 	}
 
-	return fmt.Errorf("Error from Incapsula service when deleting data center (dc_id: %s): %s", dcID, string(responseBody))
+	return newAPIError(resp, responseBody, "Error from Incapsula service when deleting data center (dc_id: %s)", dcID)
 }
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -15,28 +16,29 @@ const endpointDataCenterServerDelete = "sites/dataCenters/servers/delete"
 
 // DataCenterServerAddResponse contains id of server
 type DataCenterServerAddResponse struct {
-	Res      interface{} `json:"res"`
-	ServerID string      `json:"server_id"`
+	Res      ResCode `json:"res"`
+	ServerID string  `json:"server_id"`
 }
 
 // DataCenterServerEditResponse contains data center id
 type DataCenterServerEditResponse struct {
-	Res          interface{} `json:"res"`
-	DataCenterID string      `json:"datacenter_id"`
+	Res          ResCode `json:"res"`
+	DataCenterID string  `json:"datacenter_id"`
 }
 
 // AddDataCenterServer adds an incap data center server to be managed by Incapsula
-func (c *Client) AddDataCenterServer(dcID, serverAddress, isStandby string) (*DataCenterServerAddResponse, error) {
+func (c *Client) AddDataCenterServer(ctx context.Context, dcID, serverAddress, isStandby string, accountID int) (*DataCenterServerAddResponse, error) {
 	log.Printf("[INFO] Adding Incapsula data center server for dcID: %s\n", dcID)
 
-	// Post form to Incapsula
-	resp, err := c.httpClient.PostForm(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataCenterServerAdd), url.Values{
-		"api_id":         {c.config.APIID},
-		"api_key":        {c.config.APIKey},
+	values := url.Values{
 		"dc_id":          {dcID},
 		"server_address": {serverAddress},
 		"is_standby":     {isStandby},
-	})
+	}
+	c.setAccountID(values, accountID)
+
+	// Post form to Incapsula
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataCenterServerAdd), values, false)
 	if err != nil {
 		return nil, fmt.Errorf("Error from Incapsula service when adding data center server for dcID %s: %s", dcID, err)
 	}
@@ -52,40 +54,31 @@ func (c *Client) AddDataCenterServer(dcID, serverAddress, isStandby string) (*Da
 	var dataCenterServerAddResponse DataCenterServerAddResponse
 	err = json.Unmarshal([]byte(responseBody), &dataCenterServerAddResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing add data center server JSON response for dcID %s: %s\nresponse: %s", dcID, err, string(responseBody))
-	}
-
-	// Res can sometimes oscillate between a string and number
-	// We need to add safeguards for this inside the provider
-	var resString string
-
-	if resNumber, ok := dataCenterServerAddResponse.Res.(float64); ok {
-		resString = fmt.Sprintf("%d", int(resNumber))
-	} else {
-		resString = dataCenterServerAddResponse.Res.(string)
+		return nil, fmt.Errorf("Error parsing add data center server JSON response for dcID %s: %s\nresponse: %s", dcID, err, redactSecrets(string(responseBody)))
 	}
 
 	// Look at the response status code from Incapsula
-	if resString != "0" {
-		return nil, fmt.Errorf("Error from Incapsula service when adding data center server for dcID %s: %s", dcID, string(responseBody))
+	if dataCenterServerAddResponse.Res != 0 {
+		return nil, newAPIError(resp, responseBody, "Error from Incapsula service when adding data center server for dcID %s", dcID)
 	}
 
 	return &dataCenterServerAddResponse, nil
 }
 
 // EditDataCenterServer edits the Incapsula data center server
-func (c *Client) EditDataCenterServer(serverID, serverAddress, isStandby, isEnabled string) (*DataCenterServerEditResponse, error) {
+func (c *Client) EditDataCenterServer(ctx context.Context, serverID, serverAddress, isStandby, isEnabled string, accountID int) (*DataCenterServerEditResponse, error) {
 	log.Printf("[INFO] Editing Incapsula data center server for serverID: %s\n", serverID)
 
-	// Post form to Incapsula
-	resp, err := c.httpClient.PostForm(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataCenterServerEdit), url.Values{
-		"api_id":         {c.config.APIID},
-		"api_key":        {c.config.APIKey},
+	values := url.Values{
 		"server_id":      {serverID},
 		"server_address": {serverAddress},
 		"is_standby":     {isStandby},
 		"is_enabled":     {isEnabled},
-	})
+	}
+	c.setAccountID(values, accountID)
+
+	// Post form to Incapsula
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataCenterServerEdit), values, true)
 	if err != nil {
 		return nil, fmt.Errorf("Error editing data center server for serverID: %s: %s", serverID, err)
 	}
@@ -104,41 +97,32 @@ func (c *Client) EditDataCenterServer(serverID, serverAddress, isStandby, isEnab
 		return nil, fmt.Errorf("Error parsing edit data center server JSON response for serverID %s: %s", serverID, err)
 	}
 
-	// Res can sometimes oscillate between a string and number
-	// We need to add safeguards for this inside the provider
-	var resString string
-
-	if resNumber, ok := dataCenterServerEditResponse.Res.(float64); ok {
-		resString = fmt.Sprintf("%d", int(resNumber))
-	} else {
-		resString = dataCenterServerEditResponse.Res.(string)
-	}
-
 	// Look at the response status code from Incapsula
-	if resString != "0" {
-		return nil, fmt.Errorf("Error from Incapsula service when editing data center server for serverID %s: %s", serverID, string(responseBody))
+	if dataCenterServerEditResponse.Res != 0 {
+		return nil, newAPIError(resp, responseBody, "Error from Incapsula service when editing data center server for serverID %s", serverID)
 	}
 
 	return &dataCenterServerEditResponse, nil
 }
 
 // DeleteDataCenterServer deletes a data center server currently managed by Incapsula
-func (c *Client) DeleteDataCenterServer(serverID string) error {
+func (c *Client) DeleteDataCenterServer(ctx context.Context, serverID string, accountID int) error {
 	// Specifically shaded this struct, no need to share across funcs or export
 	// We only care about the response code and possibly the message
 	type DataCenterServerDeleteResponse struct {
-		Res      interface{} `json:"res"`
-		ServerID string      `json:"server_id"`
+		Res      ResCode `json:"res"`
+		ServerID string  `json:"server_id"`
 	}
 
 	log.Printf("[INFO] Deleting Incapsula data center server ID: %s\n", serverID)
 
-	// Post form to Incapsula
-	resp, err := c.httpClient.PostForm(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataCenterServerDelete), url.Values{
-		"api_id":    {c.config.APIID},
-		"api_key":   {c.config.APIKey},
+	values := url.Values{
 		"server_id": {serverID},
-	})
+	}
+	c.setAccountID(values, accountID)
+
+	// Post form to Incapsula
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataCenterServerDelete), values, true)
 	if err != nil {
 		return fmt.Errorf("Error deleting data center server (server_id: %s): %s", serverID, err)
 	}
@@ -157,20 +141,10 @@ func (c *Client) DeleteDataCenterServer(serverID string) error {
 		return fmt.Errorf("Error parsing delete data center server JSON response (server_id: %s): %s", serverID, err)
 	}
 
-	// Res can sometimes oscillate between a string and number
-	// We need to add safeguards for this inside the provider
-	var resString string
-
-	if resNumber, ok := dataCenterServerDeleteResponse.Res.(float64); ok {
-		resString = fmt.Sprintf("%d", int(resNumber))
-	} else {
-		resString = dataCenterServerDeleteResponse.Res.(string)
-	}
-
 	// Look at the response status code from Incapsula
-	if resString == "0" || resString == "2" {
+	if dataCenterServerDeleteResponse.Res == 0 || dataCenterServerDeleteResponse.Res == 2 {
 		return nil
 	}
 
-	return fmt.Errorf("Error from Incapsula service when deleting data center server (server_id: %s): %s", serverID, string(responseBody))
+	return newAPIError(resp, responseBody, "Error from Incapsula service when deleting data center server (server_id: %s)", serverID)
 }
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -17,7 +18,7 @@ func TestClientAddDataCenterServerBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	dcID := "42"
-	addDataCenterServerResponse, err := client.AddDataCenterServer(dcID, "", "")
+	addDataCenterServerResponse, err := client.AddDataCenterServer(context.Background(), dcID, "", "", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -41,7 +42,7 @@ func TestClientAddDataCenterServerBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	dcID := "42"
-	addDataCenterServerResponse, err := client.AddDataCenterServer(dcID, "", "")
+	addDataCenterServerResponse, err := client.AddDataCenterServer(context.Background(), dcID, "", "", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -65,7 +66,7 @@ func TestClientAddDataCenterServerInvalidRule(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	dcID := "42"
-	addDataCenterServerResponse, err := client.AddDataCenterServer(dcID, "", "")
+	addDataCenterServerResponse, err := client.AddDataCenterServer(context.Background(), dcID, "", "", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -89,14 +90,14 @@ func TestClientAddDataCenterServerValidRule(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	dcID := "42"
-	addDataCenterServerResponse, err := client.AddDataCenterServer(dcID, "", "")
+	addDataCenterServerResponse, err := client.AddDataCenterServer(context.Background(), dcID, "", "", 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
 	if addDataCenterServerResponse == nil {
 		t.Errorf("Should not have received a nil addDataCenterServerResponse instance")
 	}
-	if addDataCenterServerResponse.Res != "0" {
+	if addDataCenterServerResponse.Res != 0 {
 		t.Errorf("Response code doesn't match")
 	}
 }
@@ -109,7 +110,7 @@ func TestClientEditDataCenterServerBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	serverID := "411"
-	editDataCenterResponse, err := client.EditDataCenterServer(serverID, "", "", "")
+	editDataCenterResponse, err := client.EditDataCenterServer(context.Background(), serverID, "", "", "", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -133,7 +134,7 @@ func TestClientEditDataCenterServerBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	serverID := "411"
-	editDataCenterResponse, err := client.EditDataCenterServer(serverID, "", "", "")
+	editDataCenterResponse, err := client.EditDataCenterServer(context.Background(), serverID, "", "", "", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -157,7 +158,7 @@ func TestClientEditDataCenterServerInvalidRule(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	serverID := "411"
-	editDataCenterResponse, err := client.EditDataCenterServer(serverID, "", "", "")
+	editDataCenterResponse, err := client.EditDataCenterServer(context.Background(), serverID, "", "", "", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -181,7 +182,7 @@ func TestClientEditDataCenterServerValidRule(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	serverID := "411"
-	editDataCenterResponse, err := client.EditDataCenterServer(serverID, "", "", "")
+	editDataCenterResponse, err := client.EditDataCenterServer(context.Background(), serverID, "", "", "", 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -189,7 +190,7 @@ func TestClientEditDataCenterServerValidRule(t *testing.T) {
 		t.Errorf("Should not have received a nil editDataCenterResponse instance")
 	}
 	// todo: test response properties
-	if editDataCenterResponse.Res != "0" {
+	if editDataCenterResponse.Res != 0 {
 		t.Errorf("Response code doesn't match")
 	}
 }
@@ -202,7 +203,7 @@ func TestClientDeleteDataCenterServerBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	serverID := "42"
-	err := client.DeleteDataCenterServer(serverID)
+	err := client.DeleteDataCenterServer(context.Background(), serverID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -223,7 +224,7 @@ func TestClientDeleteDataCenterServerBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	serverID := "42"
-	err := client.DeleteDataCenterServer(serverID)
+	err := client.DeleteDataCenterServer(context.Background(), serverID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -244,7 +245,7 @@ func TestClientDeleteDataCenterServerInvalidRule(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	serverID := "42"
-	err := client.DeleteDataCenterServer(serverID)
+	err := client.DeleteDataCenterServer(context.Background(), serverID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -265,7 +266,25 @@ func TestClientDeleteDataCenterServerValidSite(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	serverID := "42"
-	err := client.DeleteDataCenterServer(serverID)
+	err := client.DeleteDataCenterServer(context.Background(), serverID, 0)
+	if err != nil {
+		t.Errorf("Should not have received an error")
+	}
+}
+
+func TestClientDeleteDataCenterServerAccountID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if accountID := req.FormValue("account_id"); accountID != "5678" {
+			t.Errorf("Should have sent the account_id override, got: %s", accountID)
+		}
+		rw.Write([]byte(`{"res":"0","res_message":"OK"}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL, AccountID: 1234}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	serverID := "42"
+	err := client.DeleteDataCenterServer(context.Background(), serverID, 5678)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
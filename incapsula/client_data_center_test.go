@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -17,7 +18,7 @@ func TestClientAddDataCenterBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := "42"
-	addDataCenterResponse, err := client.AddDataCenter(siteID, "", "", "")
+	addDataCenterResponse, err := client.AddDataCenter(context.Background(), siteID, "", "", "", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -41,7 +42,7 @@ func TestClientAddDataCenterBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "42"
-	addDataCenterResponse, err := client.AddDataCenter(siteID, "", "", "")
+	addDataCenterResponse, err := client.AddDataCenter(context.Background(), siteID, "", "", "", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -65,7 +66,7 @@ func TestClientAddDataCenterInvalidRule(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "42"
-	addDataCenterResponse, err := client.AddDataCenter(siteID, "", "", "")
+	addDataCenterResponse, err := client.AddDataCenter(context.Background(), siteID, "", "", "", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -89,14 +90,14 @@ func TestClientAddDataCenterValidRule(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "42"
-	addDataCenterResponse, err := client.AddDataCenter(siteID, "", "", "")
+	addDataCenterResponse, err := client.AddDataCenter(context.Background(), siteID, "", "", "", 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
 	if addDataCenterResponse == nil {
 		t.Errorf("Should not have received a nil addDataCenterResponse instance")
 	}
-	if addDataCenterResponse.Res != "0" {
+	if addDataCenterResponse.Res != 0 {
 		t.Errorf("Response code doesn't match")
 	}
 }
@@ -109,7 +110,7 @@ func TestClientListDataCentersBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := "42"
-	listDataCentersResponse, err := client.ListDataCenters(siteID)
+	listDataCentersResponse, err := client.ListDataCenters(context.Background(), siteID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -133,7 +134,7 @@ func TestClientListDataCentersBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "42"
-	listDataCentersResponse, err := client.ListDataCenters(siteID)
+	listDataCentersResponse, err := client.ListDataCenters(context.Background(), siteID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -157,7 +158,7 @@ func TestClientListDataCentersInvalidRequest(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "42"
-	listDataCentersResponse, err := client.ListDataCenters(siteID)
+	listDataCentersResponse, err := client.ListDataCenters(context.Background(), siteID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -181,7 +182,7 @@ func TestClientListDataCentersValidRequest(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "42"
-	listDataCentersResponse, err := client.ListDataCenters(siteID)
+	listDataCentersResponse, err := client.ListDataCenters(context.Background(), siteID, 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -189,11 +190,32 @@ func TestClientListDataCentersValidRequest(t *testing.T) {
 		t.Errorf("Should not have received a nil listDataCentersResponse instance")
 	}
 
-	if listDataCentersResponse.Res != "0" {
+	if listDataCentersResponse.Res != 0 {
 		t.Errorf("Response code doesn't match")
 	}
 }
 
+func TestClientListDataCentersAccountID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.String() != fmt.Sprintf("/%s", endpointDataCenterList) {
+			t.Errorf("Should have have hit /%s endpoint. Got: %s", endpointDataCenterList, req.URL.String())
+		}
+		if accountID := req.FormValue("account_id"); accountID != "1234" {
+			t.Errorf("Should have sent the provider account_id, got: %s", accountID)
+		}
+		rw.Write([]byte(`{"res":"0"}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL, AccountID: 1234}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	siteID := "42"
+	_, err := client.ListDataCenters(context.Background(), siteID, 0)
+	if err != nil {
+		t.Errorf("Should not have received an error")
+	}
+}
+
 ////////////////////////////////////////////////////////////////
 // EditDataCenter Tests
 ////////////////////////////////////////////////////////////////
@@ -205,7 +227,7 @@ func TestClientEditDataCenterBadConnection(t *testing.T) {
 	name := "foo"
 	isContent := "yes"
 	isActive := "yes"
-	editDataCenterResponse, err := client.EditDataCenter(dcID, name, isContent, isActive)
+	editDataCenterResponse, err := client.EditDataCenter(context.Background(), dcID, name, isContent, isActive, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -232,7 +254,7 @@ func TestClientEditDataCenterBadJSON(t *testing.T) {
 	name := "foo"
 	isContent := "yes"
 	isActive := "yes"
-	editDataCenterResponse, err := client.EditDataCenter(dcID, name, isContent, isActive)
+	editDataCenterResponse, err := client.EditDataCenter(context.Background(), dcID, name, isContent, isActive, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -259,7 +281,7 @@ func TestClientEditDataCenterInvalidRule(t *testing.T) {
 	name := "foo"
 	isContent := "yes"
 	isActive := "yes"
-	editDataCenterResponse, err := client.EditDataCenter(dcID, name, isContent, isActive)
+	editDataCenterResponse, err := client.EditDataCenter(context.Background(), dcID, name, isContent, isActive, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -286,7 +308,7 @@ func TestClientEditDataCenterValidRule(t *testing.T) {
 	name := "foo"
 	isContent := "yes"
 	isActive := "yes"
-	editDataCenterResponse, err := client.EditDataCenter(dcID, name, isContent, isActive)
+	editDataCenterResponse, err := client.EditDataCenter(context.Background(), dcID, name, isContent, isActive, 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -294,7 +316,7 @@ func TestClientEditDataCenterValidRule(t *testing.T) {
 		t.Errorf("Should not have received a nil editDataCenterResponse instance")
 	}
 
-	if editDataCenterResponse.Res != "0" {
+	if editDataCenterResponse.Res != 0 {
 		t.Errorf("Response code doesn't match")
 	}
 }
@@ -307,7 +329,7 @@ func TestClientDeleteDataCenterBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	dcID := "42"
-	err := client.DeleteDataCenter(dcID)
+	err := client.DeleteDataCenter(context.Background(), dcID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -328,7 +350,7 @@ func TestClientDeleteDataCenterBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	dcID := "42"
-	err := client.DeleteDataCenter(dcID)
+	err := client.DeleteDataCenter(context.Background(), dcID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -349,7 +371,7 @@ func TestClientDeleteDataCenterInvalidRule(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	dcID := "42"
-	err := client.DeleteDataCenter(dcID)
+	err := client.DeleteDataCenter(context.Background(), dcID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -370,7 +392,7 @@ func TestClientDeleteDataCenterValidSite(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	dcID := "42"
-	err := client.DeleteDataCenter(dcID)
+	err := client.DeleteDataCenter(context.Background(), dcID, 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -13,24 +14,25 @@ const endpointDataStorageRegionUpdate = "sites/data-privacy/region-change"
 
 // DataStorageRegionResponse contains the relevant information when getting/setting a data storage region
 type DataStorageRegionResponse struct {
-	Region     string `json:"region"`
-	Res        int    `json:"res"`
-	ResMessage string `json:"res_message"`
+	Region     string  `json:"region"`
+	Res        ResCode `json:"res"`
+	ResMessage string  `json:"res_message"`
 	DebugInfo  struct {
 		IDInfo string `json:"id-info"`
 	} `json:"debug_info"`
 }
 
 // GetDataStorageRegion gets the data storage region for the site
-func (c *Client) GetDataStorageRegion(siteID string) (*DataStorageRegionResponse, error) {
+func (c *Client) GetDataStorageRegion(ctx context.Context, siteID string, accountID int) (*DataStorageRegionResponse, error) {
 	log.Printf("[INFO] Getting Incapsula data storage region for site: %s\n", siteID)
 
-	// Post form to Incapsula
-	resp, err := c.httpClient.PostForm(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataStorageRegionGet), url.Values{
-		"api_id":  {c.config.APIID},
-		"api_key": {c.config.APIKey},
+	values := url.Values{
 		"site_id": {siteID},
-	})
+	}
+	c.setAccountID(values, accountID)
+
+	// Post form to Incapsula
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataStorageRegionGet), values, true)
 	if err != nil {
 		return nil, fmt.Errorf("Error getting data storage region for site id: %s: %s", siteID, err)
 	}
@@ -51,23 +53,24 @@ func (c *Client) GetDataStorageRegion(siteID string) (*DataStorageRegionResponse
 
 	// Look at the response status code from Incapsula
 	if dataStorageRegionResponse.Res != 0 {
-		return &dataStorageRegionResponse, fmt.Errorf("Error from Incapsula service when getting site data storage region for site id: %s: %s", siteID, string(responseBody))
+		return &dataStorageRegionResponse, newAPIError(resp, responseBody, "Error from Incapsula service when getting site data storage region for site id: %s", siteID)
 	}
 
 	return &dataStorageRegionResponse, nil
 }
 
 // UpdateDataStorageRegion will update the data storage region on the site
-func (c *Client) UpdateDataStorageRegion(siteID, region string) (*DataStorageRegionResponse, error) {
+func (c *Client) UpdateDataStorageRegion(ctx context.Context, siteID, region string, accountID int) (*DataStorageRegionResponse, error) {
 	log.Printf("[INFO] Updating Incapsula site data storage region (%s) for siteID: %s\n", region, siteID)
 
-	// Post form to Incapsula
-	resp, err := c.httpClient.PostForm(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataStorageRegionUpdate), url.Values{
-		"api_id":              {c.config.APIID},
-		"api_key":             {c.config.APIKey},
+	values := url.Values{
 		"site_id":             {siteID},
 		"data_storage_region": {region},
-	})
+	}
+	c.setAccountID(values, accountID)
+
+	// Post form to Incapsula
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataStorageRegionUpdate), values, true)
 	if err != nil {
 		return nil, fmt.Errorf("Error updating data storage region with value (%s) on site_id: %s: %s", region, siteID, err)
 	}
@@ -88,7 +91,7 @@ func (c *Client) UpdateDataStorageRegion(siteID, region string) (*DataStorageReg
 
 	// Look at the response status code from Incapsula
 	if dataStorageRegionResponse.Res != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when updating site data storage region for siteID %s: %s", siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, "Error from Incapsula service when updating site data storage region for siteID %s", siteID)
 	}
 
 	return &dataStorageRegionResponse, nil
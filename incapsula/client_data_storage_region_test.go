@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -17,7 +18,7 @@ func TestClientGetDataStorageRegionBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := "123"
-	dataStorageRegionResponse, err := client.GetDataStorageRegion(siteID)
+	dataStorageRegionResponse, err := client.GetDataStorageRegion(context.Background(), siteID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -41,7 +42,7 @@ func TestClientGetDataStorageRegionBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "123"
-	dataStorageRegionResponse, err := client.GetDataStorageRegion(siteID)
+	dataStorageRegionResponse, err := client.GetDataStorageRegion(context.Background(), siteID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -65,7 +66,7 @@ func TestClientGetDataStorageRegionInvalidSite(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "7289383"
-	dataStorageRegionResponse, err := client.GetDataStorageRegion(siteID)
+	dataStorageRegionResponse, err := client.GetDataStorageRegion(context.Background(), siteID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -89,7 +90,7 @@ func TestClientGetDataStorageRegionValidSite(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "123"
-	dataStorageRegionResponse, err := client.GetDataStorageRegion(siteID)
+	dataStorageRegionResponse, err := client.GetDataStorageRegion(context.Background(), siteID, 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -104,6 +105,23 @@ func TestClientGetDataStorageRegionValidSite(t *testing.T) {
 	}
 }
 
+func TestClientGetDataStorageRegionAccountID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if accountID := req.FormValue("account_id"); accountID != "1234" {
+			t.Errorf("Should have sent the provider account_id, got: %s", accountID)
+		}
+		rw.Write([]byte(`{"region":"US","res":0}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL, AccountID: 1234}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	_, err := client.GetDataStorageRegion(context.Background(), "123", 0)
+	if err != nil {
+		t.Errorf("Should not have received an error")
+	}
+}
+
 ////////////////////////////////////////////////////////////////
 // UpdateSite Tests
 ////////////////////////////////////////////////////////////////
@@ -113,7 +131,7 @@ func TestClientUpdateDataStorageRegionBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := "42"
 	region := "US"
-	dataStorageRegionResponse, err := client.UpdateDataStorageRegion(siteID, region)
+	dataStorageRegionResponse, err := client.UpdateDataStorageRegion(context.Background(), siteID, region, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -138,7 +156,7 @@ func TestClientUpdateDataStorageRegionBadJSON(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "42"
 	region := "US"
-	dataStorageRegionResponse, err := client.UpdateDataStorageRegion(siteID, region)
+	dataStorageRegionResponse, err := client.UpdateDataStorageRegion(context.Background(), siteID, region, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -163,7 +181,7 @@ func TestClientUpdateDataStorageRegionInvalidSite(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "7293873"
 	region := "US"
-	dataStorageRegionResponse, err := client.UpdateDataStorageRegion(siteID, region)
+	dataStorageRegionResponse, err := client.UpdateDataStorageRegion(context.Background(), siteID, region, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -188,7 +206,7 @@ func TestClientUpdateDataStorageRegionValidSite(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "7293873"
 	region := "US"
-	dataStorageRegionResponse, err := client.UpdateDataStorageRegion(siteID, region)
+	dataStorageRegionResponse, err := client.UpdateDataStorageRegion(context.Background(), siteID, region, 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -0,0 +1,138 @@
+package incapsula
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// APIv1 response codes (res) with a special meaning for the provider
+const (
+	resCodeUnknownAccount = 9403 // Unknown/unauthorized account_id
+	resCodeAuthFailed     = 9411 // Authentication parameters missing or incorrect
+	resCodeUnknownSite    = 9413 // Unknown/unauthorized site_id
+)
+
+// ResCode is an APIv1 response code (res)
+// Depending on the endpoint, the API sends it either as a number or as a string
+type ResCode int
+
+// UnmarshalJSON accepts numbers, numeric strings and null (decoded as 0)
+func (r *ResCode) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	switch res := value.(type) {
+	case nil:
+		*r = 0
+	case float64:
+		if res != math.Trunc(res) {
+			return fmt.Errorf("Invalid res code: %s", string(data))
+		}
+		*r = ResCode(res)
+	case string:
+		res = strings.TrimSpace(res)
+		if res == "" {
+			*r = 0
+			return nil
+		}
+		resNumber, err := strconv.Atoi(res)
+		if err != nil {
+			return fmt.Errorf("Invalid res code: %s", string(data))
+		}
+		*r = ResCode(resNumber)
+	default:
+		return fmt.Errorf("Invalid res code: %s", string(data))
+	}
+
+	return nil
+}
+
+// APIError is returned when the Incapsula API rejects a request
+type APIError struct {
+	// Endpoint (URL path) the request was sent to
+	Endpoint string
+
+	// HTTP status code of the response
+	StatusCode int
+
+	// APIv1 response code, 0 when the response does not carry one
+	Res int
+
+	// APIv1 response message
+	ResMessage string
+
+	// APIv1 debug information, e.g. id-info
+	DebugInfo map[string]interface{}
+
+	message string
+	body    string
+}
+
+// newAPIError builds an APIError from the response of a failed request
+// The message describes the failed operation, the (redacted) response body is appended to it
+func newAPIError(resp *http.Response, responseBody []byte, format string, args ...interface{}) *APIError {
+	apiError := &APIError{
+		StatusCode: resp.StatusCode,
+		message:    fmt.Sprintf(format, args...),
+		body:       string(responseBody),
+	}
+
+	if resp.Request != nil && resp.Request.URL != nil {
+		apiError.Endpoint = resp.Request.URL.Path
+	}
+
+	// APIv2 and API responses do not carry these fields, ignore anything we can't make sense of
+	var responseStatus struct {
+		Res        ResCode                `json:"res"`
+		ResMessage string                 `json:"res_message"`
+		DebugInfo  map[string]interface{} `json:"debug_info"`
+	}
+	if err := json.Unmarshal(responseBody, &responseStatus); err == nil {
+		apiError.Res = int(responseStatus.Res)
+		apiError.ResMessage = responseStatus.ResMessage
+		apiError.DebugInfo = responseStatus.DebugInfo
+	}
+
+	return apiError
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s: %s", e.message, redactSecrets(e.body))
+}
+
+// IsNotFound reports whether the error indicates that the object (or its site) no longer exists
+func IsNotFound(err error) bool {
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		return false
+	}
+	return apiError.StatusCode == http.StatusNotFound || apiError.Res == resCodeUnknownSite
+}
+
+// IsThrottled reports whether the error indicates that the request was rate limited
+func IsThrottled(err error) bool {
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		return false
+	}
+	return apiError.StatusCode == http.StatusTooManyRequests
+}
+
+// IsAuthError reports whether the error indicates that the API credentials were rejected
+func IsAuthError(err error) bool {
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		return false
+	}
+	return apiError.StatusCode == http.StatusUnauthorized ||
+		apiError.StatusCode == http.StatusForbidden ||
+		apiError.Res == resCodeAuthFailed ||
+		apiError.Res == resCodeUnknownAccount
+}
@@ -0,0 +1,193 @@
+package incapsula
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////
+// APIError Tests
+////////////////////////////////////////////////////////////////
+
+func TestClientSiteStatusUnknownSiteAPIError(t *testing.T) {
+	apiID := "foo"
+	apiKey := "bar"
+	domain := "www.example.com"
+	siteID := 42
+
+	endpoint := fmt.Sprintf("/%s", endpointSiteStatus)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(200)
+		if req.URL.String() != endpoint {
+			t.Errorf("Should have have hit /%s endpoint. Got: %s", endpointSiteStatus, req.URL.String())
+		}
+		rw.Write([]byte(`{"res":"9413","res_message":"Unknown/unauthorized site_id","debug_info":{"id-info":"999999"}}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	_, err := client.SiteStatus(context.Background(), domain, siteID, 0)
+	if err == nil {
+		t.Fatalf("Should have received an error")
+	}
+
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		t.Fatalf("Should have received an APIError, got: %T", err)
+	}
+	if apiError.StatusCode != 200 {
+		t.Errorf("Should have received a 200 status code, got: %d", apiError.StatusCode)
+	}
+	if apiError.Res != 9413 {
+		t.Errorf("Should have received res 9413, got: %d", apiError.Res)
+	}
+	if apiError.ResMessage != "Unknown/unauthorized site_id" {
+		t.Errorf("Should have received the res_message, got: %s", apiError.ResMessage)
+	}
+	if apiError.DebugInfo["id-info"] != "999999" {
+		t.Errorf("Should have received the debug_info, got: %v", apiError.DebugInfo)
+	}
+	if apiError.Endpoint != endpoint {
+		t.Errorf("Should have received the %s endpoint, got: %s", endpoint, apiError.Endpoint)
+	}
+	if !IsNotFound(err) {
+		t.Errorf("Should have received a not found error")
+	}
+	if IsThrottled(err) || IsAuthError(err) {
+		t.Errorf("Should only have received a not found error")
+	}
+}
+
+func TestClientReadIncapRuleNotFoundAPIError(t *testing.T) {
+	apiID := "foo"
+	apiKey := "bar"
+	siteID := "42"
+	ruleID := 1000
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(404)
+		rw.Write([]byte(`{"errors":[{"status":404,"title":"Not Found"}]}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	_, err := client.ReadIncapRule(context.Background(), siteID, ruleID, 0)
+	if !IsNotFound(err) {
+		t.Errorf("Should have received a not found error, got: %s", err)
+	}
+}
+
+func TestAPIErrorHelpers(t *testing.T) {
+	cases := []struct {
+		err       error
+		notFound  bool
+		throttled bool
+		authError bool
+	}{
+		{&APIError{StatusCode: 200, Res: 0}, false, false, false},
+		{&APIError{StatusCode: 404}, true, false, false},
+		{&APIError{StatusCode: 200, Res: 9413}, true, false, false},
+		{&APIError{StatusCode: 429}, false, true, false},
+		{&APIError{StatusCode: 401}, false, false, true},
+		{&APIError{StatusCode: 403}, false, false, true},
+		{&APIError{StatusCode: 200, Res: 9411}, false, false, true},
+		{&APIError{StatusCode: 200, Res: 9403}, false, false, true},
+		{fmt.Errorf("Error parsing site status JSON response"), false, false, false},
+		{nil, false, false, false},
+	}
+
+	for _, c := range cases {
+		if IsNotFound(c.err) != c.notFound {
+			t.Errorf("IsNotFound(%#v) should be %t", c.err, c.notFound)
+		}
+		if IsThrottled(c.err) != c.throttled {
+			t.Errorf("IsThrottled(%#v) should be %t", c.err, c.throttled)
+		}
+		if IsAuthError(c.err) != c.authError {
+			t.Errorf("IsAuthError(%#v) should be %t", c.err, c.authError)
+		}
+	}
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	err := newAPIError(&http.Response{StatusCode: 200}, []byte(`{"res":1,"res_message":"Unexpected error"}`), "Error from Incapsula service when updating site for siteID %s", "42")
+	expected := `Error from Incapsula service when updating site for siteID 42: {"res":1,"res_message":"Unexpected error"}`
+	if err.Error() != expected {
+		t.Errorf("Should have received %s, got: %s", expected, err.Error())
+	}
+}
+
+////////////////////////////////////////////////////////////////
+// ResCode Tests
+////////////////////////////////////////////////////////////////
+
+func TestResCodeUnmarshalJSON(t *testing.T) {
+	cases := map[string]ResCode{
+		`{"res":0}`:      0,
+		`{"res":9413}`:   9413,
+		`{"res":"0"}`:    0,
+		`{"res":"9413"}`: 9413,
+		`{"res":" 2 "}`:  2,
+		`{"res":""}`:     0,
+		`{"res":null}`:   0,
+		`{}`:             0,
+	}
+
+	for data, expected := range cases {
+		var response struct {
+			Res ResCode `json:"res"`
+		}
+		if err := json.Unmarshal([]byte(data), &response); err != nil {
+			t.Errorf("Should not have received an error for %s, got: %s", data, err)
+		}
+		if response.Res != expected {
+			t.Errorf("Should have decoded %s as %d, got: %d", data, expected, response.Res)
+		}
+	}
+}
+
+func TestResCodeUnmarshalJSONInvalid(t *testing.T) {
+	for _, data := range []string{`{"res":"ok"}`, `{"res":1.5}`, `{"res":true}`, `{"res":{"code":1}}`, `{"res":[0]}`} {
+		var response struct {
+			Res ResCode `json:"res"`
+		}
+		if err := json.Unmarshal([]byte(data), &response); err == nil {
+			t.Errorf("Should have received an error for %s", data)
+		}
+	}
+}
+
+func TestClientSiteStatusMalformedRes(t *testing.T) {
+	apiID := "foo"
+	apiKey := "bar"
+	domain := "www.example.com"
+	siteID := 42
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(200)
+		rw.Write([]byte(`{"res":{"unexpected":true},"site_id":42}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	siteStatusResponse, err := client.SiteStatus(context.Background(), domain, siteID, 0)
+	if err == nil {
+		t.Errorf("Should have received an error")
+	}
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error parsing site status JSON response for domain %s (site id: %d)", domain, siteID)) {
+		t.Errorf("Should have received a JSON parse error, got: %s", err)
+	}
+	if siteStatusResponse != nil {
+		t.Errorf("Should have received a nil siteStatusResponse instance")
+	}
+}
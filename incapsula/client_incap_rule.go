@@ -1,7 +1,7 @@
 package incapsula
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -34,7 +34,7 @@ type IncapRuleWithID struct {
 }
 
 // AddIncapRule adds an incap rule to be managed by Incapsula
-func (c *Client) AddIncapRule(siteID string, rule *IncapRule) (*IncapRuleWithID, error) {
+func (c *Client) AddIncapRule(ctx context.Context, siteID string, rule *IncapRule, accountID int) (*IncapRuleWithID, error) {
 	log.Printf("[INFO] Adding Incapsula Incap Rule for Site ID %s\n", siteID)
 
 	ruleJSON, err := json.Marshal(rule)
@@ -43,10 +43,11 @@ func (c *Client) AddIncapRule(siteID string, rule *IncapRule) (*IncapRuleWithID,
 	}
 
 	// Post form to Incapsula
-	resp, err := c.httpClient.Post(
-		fmt.Sprintf("%s/sites/%s/rules?api_id=%s&api_key=%s", c.config.BaseURLRev2, siteID, c.config.APIID, c.config.APIKey),
-		"application/json",
-		bytes.NewReader(ruleJSON))
+	resp, err := c.doJSONRequest(
+		ctx,
+		http.MethodPost,
+		c.withAccountID(fmt.Sprintf("%s/sites/%s/rules", c.config.BaseURLRev2, siteID), accountID),
+		ruleJSON)
 	if err != nil {
 		return nil, fmt.Errorf("Error from Incapsula service when adding Incap Rule for Site ID %s: %s", siteID, err)
 	}
@@ -60,27 +61,27 @@ func (c *Client) AddIncapRule(siteID string, rule *IncapRule) (*IncapRuleWithID,
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when adding Incap Rule for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, "Error status code %d from Incapsula service when adding Incap Rule for Site ID %s", resp.StatusCode, siteID)
 	}
 
 	// Parse the JSON
 	var incapRuleWithID IncapRuleWithID
 	err = json.Unmarshal([]byte(responseBody), &incapRuleWithID)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Incap Rule JSON response for Site ID %s: %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Incap Rule JSON response for Site ID %s: %s\nresponse: %s", siteID, err, redactSecrets(string(responseBody)))
 	}
 
 	return &incapRuleWithID, nil
 }
 
 // ReadIncapRule gets the specific Incap Rule
-func (c *Client) ReadIncapRule(siteID string, ruleID int) (*IncapRuleWithID, int, error) {
+func (c *Client) ReadIncapRule(ctx context.Context, siteID string, ruleID int, accountID int) (*IncapRuleWithID, error) {
 	log.Printf("[INFO] Getting Incapsula Incap Rule %d for Site ID %s\n", ruleID, siteID)
 
 	// Post form to Incapsula
-	resp, err := c.httpClient.Get(fmt.Sprintf("%s/sites/%s/rules/%d?api_id=%s&api_key=%s", c.config.BaseURLRev2, siteID, ruleID, c.config.APIID, c.config.APIKey))
+	resp, err := c.doJSONRequest(ctx, http.MethodGet, c.withAccountID(fmt.Sprintf("%s/sites/%s/rules/%d", c.config.BaseURLRev2, siteID, ruleID), accountID), nil)
 	if err != nil {
-		return nil, 0, fmt.Errorf("Error from Incapsula service when reading Incap Rule %d for Site ID %s: %s", ruleID, siteID, err)
+		return nil, fmt.Errorf("Error from Incapsula service when reading Incap Rule %d for Site ID %s: %s", ruleID, siteID, err)
 	}
 
 	// Read the body
@@ -92,21 +93,21 @@ func (c *Client) ReadIncapRule(siteID string, ruleID int) (*IncapRuleWithID, int
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, resp.StatusCode, fmt.Errorf("Error status code %d from Incapsula service when reading Incap Rule %d for Site ID %s: %s", resp.StatusCode, ruleID, siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, "Error status code %d from Incapsula service when reading Incap Rule %d for Site ID %s", resp.StatusCode, ruleID, siteID)
 	}
 
 	// Parse the JSON
 	var incapRuleWithID IncapRuleWithID
 	err = json.Unmarshal([]byte(responseBody), &incapRuleWithID)
 	if err != nil {
-		return nil, resp.StatusCode, fmt.Errorf("Error parsing Incap Rule %d JSON response for Site ID %s: %s\nresponse: %s", ruleID, siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Incap Rule %d JSON response for Site ID %s: %s\nresponse: %s", ruleID, siteID, err, redactSecrets(string(responseBody)))
 	}
 
-	return &incapRuleWithID, resp.StatusCode, nil
+	return &incapRuleWithID, nil
 }
 
 // UpdateIncapRule updates the Incapsula Incap Rule
-func (c *Client) UpdateIncapRule(siteID string, ruleID int, rule *IncapRule) (*IncapRuleWithID, error) {
+func (c *Client) UpdateIncapRule(ctx context.Context, siteID string, ruleID int, rule *IncapRule, accountID int) (*IncapRuleWithID, error) {
 	log.Printf("[INFO] Updating Incapsula Incap Rule %d for Site ID %s\n", ruleID, siteID)
 
 	ruleJSON, err := json.Marshal(rule)
@@ -115,14 +116,11 @@ func (c *Client) UpdateIncapRule(siteID string, ruleID int, rule *IncapRule) (*I
 	}
 
 	// Put request to Incapsula
-	req, err := http.NewRequest(
+	resp, err := c.doJSONRequest(
+		ctx,
 		http.MethodPut,
-		fmt.Sprintf("%s/sites/%s/rules/%d?api_id=%s&api_key=%s", c.config.BaseURLRev2, siteID, ruleID, c.config.APIID, c.config.APIKey),
-		bytes.NewReader(ruleJSON))
-	if err != nil {
-		return nil, fmt.Errorf("Error preparing HTTP PUT for updating Incap Rule %d for Site ID %s: %s", ruleID, siteID, err)
-	}
-	resp, err := c.httpClient.Do(req)
+		c.withAccountID(fmt.Sprintf("%s/sites/%s/rules/%d", c.config.BaseURLRev2, siteID, ruleID), accountID),
+		ruleJSON)
 	if err != nil {
 		return nil, fmt.Errorf("Error from Incapsula service when updating Incap Rule %d for Site ID %s: %s", ruleID, siteID, err)
 	}
@@ -136,32 +134,29 @@ func (c *Client) UpdateIncapRule(siteID string, ruleID int, rule *IncapRule) (*I
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when updating Incap Rule %d for Site ID %s: %s", resp.StatusCode, ruleID, siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, "Error status code %d from Incapsula service when updating Incap Rule %d for Site ID %s", resp.StatusCode, ruleID, siteID)
 	}
 
 	// Parse the JSON
 	var incapRuleWithID IncapRuleWithID
 	err = json.Unmarshal([]byte(responseBody), &incapRuleWithID)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Incap Rule %d JSON response for Site ID %s: %s\nresponse: %s", ruleID, siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Incap Rule %d JSON response for Site ID %s: %s\nresponse: %s", ruleID, siteID, err, redactSecrets(string(responseBody)))
 	}
 
 	return &incapRuleWithID, nil
 }
 
 // DeleteIncapRule deletes a site currently managed by Incapsula
-func (c *Client) DeleteIncapRule(siteID string, ruleID int) error {
+func (c *Client) DeleteIncapRule(ctx context.Context, siteID string, ruleID int, accountID int) error {
 	log.Printf("[INFO] Deleting Incapsula Incap Rule %d for Site ID %s\n", ruleID, siteID)
 
 	// Delete request to Incapsula
-	req, err := http.NewRequest(
+	resp, err := c.doJSONRequest(
+		ctx,
 		http.MethodDelete,
-		fmt.Sprintf("%s/sites/%s/rules/%d?api_id=%s&api_key=%s", c.config.BaseURLRev2, siteID, ruleID, c.config.APIID, c.config.APIKey),
+		c.withAccountID(fmt.Sprintf("%s/sites/%s/rules/%d", c.config.BaseURLRev2, siteID, ruleID), accountID),
 		nil)
-	if err != nil {
-		return fmt.Errorf("Error preparing HTTP DELETE for deleting Incap Rule %d for Site ID %s: %s", ruleID, siteID, err)
-	}
-	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("Error from Incapsula service when deleting Incap Rule %d for Site ID %s: %s", ruleID, siteID, err)
 	}
@@ -175,7 +170,7 @@ func (c *Client) DeleteIncapRule(siteID string, ruleID int) error {
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from Incapsula service when deleting Incap Rule %d for Site ID %s: %s", resp.StatusCode, ruleID, siteID, string(responseBody))
+		return newAPIError(resp, responseBody, "Error status code %d from Incapsula service when deleting Incap Rule %d for Site ID %s", resp.StatusCode, ruleID, siteID)
 	}
 
 	return nil
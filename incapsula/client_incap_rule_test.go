@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -24,7 +25,7 @@ func TestClientAddIncapRuleBadConnection(t *testing.T) {
 		Filter: "Full-URL == \"/someurl\"",
 	}
 
-	addIncapRuleResponse, err := client.AddIncapRule(siteID, &rule)
+	addIncapRuleResponse, err := client.AddIncapRule(context.Background(), siteID, &rule, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -41,7 +42,7 @@ func TestClientAddIncapRuleBadJSON(t *testing.T) {
 	apiKey := "bar"
 	siteID := "42"
 
-	endpoint := fmt.Sprintf("/sites/%s/rules?api_id=%s&api_key=%s", siteID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/rules", siteID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		if req.URL.String() != endpoint {
@@ -60,7 +61,7 @@ func TestClientAddIncapRuleBadJSON(t *testing.T) {
 		Filter: "Full-URL == \"/someurl\"",
 	}
 
-	addIncapRuleResponse, err := client.AddIncapRule(siteID, &rule)
+	addIncapRuleResponse, err := client.AddIncapRule(context.Background(), siteID, &rule, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -77,7 +78,7 @@ func TestClientAddIncapRuleInvalidRule(t *testing.T) {
 	apiKey := "bar"
 	siteID := "42"
 
-	endpoint := fmt.Sprintf("/sites/%s/rules?api_id=%s&api_key=%s", siteID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/rules", siteID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.WriteHeader(406)
@@ -95,7 +96,7 @@ func TestClientAddIncapRuleInvalidRule(t *testing.T) {
 		Name: "some_name",
 	}
 
-	addIncapRuleResponse, err := client.AddIncapRule(siteID, &rule)
+	addIncapRuleResponse, err := client.AddIncapRule(context.Background(), siteID, &rule, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -112,7 +113,7 @@ func TestClientAddIncapRuleValidRule(t *testing.T) {
 	apiKey := "bar"
 	siteID := "42"
 
-	endpoint := fmt.Sprintf("/sites/%s/rules?api_id=%s&api_key=%s", siteID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/rules", siteID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.WriteHeader(200)
@@ -132,7 +133,7 @@ func TestClientAddIncapRuleValidRule(t *testing.T) {
 		Filter: "Full-URL == \"/someurl\"",
 	}
 
-	addIncapRuleResponse, err := client.AddIncapRule(siteID, &rule)
+	addIncapRuleResponse, err := client.AddIncapRule(context.Background(), siteID, &rule, 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -154,7 +155,7 @@ func TestClientReadIncapRuleBadConnection(t *testing.T) {
 	siteID := "42"
 	ruleID := 62
 
-	readIncapRuleResponse, _, err := client.ReadIncapRule(siteID, ruleID)
+	readIncapRuleResponse, err := client.ReadIncapRule(context.Background(), siteID, ruleID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -172,7 +173,7 @@ func TestClientReadIncapRuleBadJSON(t *testing.T) {
 	siteID := "42"
 	ruleID := 62
 
-	endpoint := fmt.Sprintf("/sites/%s/rules/%d?api_id=%s&api_key=%s", siteID, ruleID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/rules/%d", siteID, ruleID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		if req.URL.String() != endpoint {
@@ -185,7 +186,7 @@ func TestClientReadIncapRuleBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readIncapRuleResponse, _, err := client.ReadIncapRule(siteID, ruleID)
+	readIncapRuleResponse, err := client.ReadIncapRule(context.Background(), siteID, ruleID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -203,7 +204,7 @@ func TestClientReadIncapRuleInvalidRule(t *testing.T) {
 	siteID := "42"
 	ruleID := 29010333
 
-	endpoint := fmt.Sprintf("/sites/%s/rules/%d?api_id=%s&api_key=%s", siteID, ruleID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/rules/%d", siteID, ruleID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.WriteHeader(404)
@@ -217,15 +218,15 @@ func TestClientReadIncapRuleInvalidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readIncapRuleResponse, statusCode, err := client.ReadIncapRule(siteID, ruleID)
+	readIncapRuleResponse, err := client.ReadIncapRule(context.Background(), siteID, ruleID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
 	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error status code 404 from Incapsula service when reading Incap Rule %d for Site ID %s", ruleID, siteID)) {
 		t.Errorf("Should have received a bad incap rule error, got: %s", err)
 	}
-	if statusCode != 404 {
-		t.Errorf("Should have received a 404 status code")
+	if !IsNotFound(err) {
+		t.Errorf("Should have received a not found error")
 	}
 	if readIncapRuleResponse != nil {
 		t.Errorf("Should have received a nil readIncapRuleResponse instance")
@@ -238,7 +239,7 @@ func TestClientReadIncapRuleValidRule(t *testing.T) {
 	siteID := "42"
 	ruleID := 290109
 
-	endpoint := fmt.Sprintf("/sites/%s/rules/%d?api_id=%s&api_key=%s", siteID, ruleID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/rules/%d", siteID, ruleID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.WriteHeader(200)
@@ -252,16 +253,13 @@ func TestClientReadIncapRuleValidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readIncapRuleResponse, statusCode, err := client.ReadIncapRule(siteID, ruleID)
+	readIncapRuleResponse, err := client.ReadIncapRule(context.Background(), siteID, ruleID, 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
 	if readIncapRuleResponse == nil {
 		t.Errorf("Should not have received a nil readIncapRuleResponse instance")
 	}
-	if statusCode != 200 {
-		t.Errorf("Should not have received a 200 status code")
-	}
 	if readIncapRuleResponse.RuleID == 0 {
 		t.Errorf("Should not have received an empty rule ID")
 	}
@@ -283,7 +281,7 @@ func TestClientUpdateIncapRuleBadConnection(t *testing.T) {
 		Filter: "Full-URL == \"/someurl\"",
 	}
 
-	updateIncapRuleResponse, err := client.UpdateIncapRule(siteID, ruleID, &rule)
+	updateIncapRuleResponse, err := client.UpdateIncapRule(context.Background(), siteID, ruleID, &rule, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -307,7 +305,7 @@ func TestClientUpdateIncapRuleBadJSON(t *testing.T) {
 		Filter: "Full-URL == \"/someurl\"",
 	}
 
-	endpoint := fmt.Sprintf("/sites/%s/rules/%d?api_id=%s&api_key=%s", siteID, ruleID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/rules/%d", siteID, ruleID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		if req.URL.String() != endpoint {
@@ -320,7 +318,7 @@ func TestClientUpdateIncapRuleBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	updateIncapRuleResponse, err := client.UpdateIncapRule(siteID, ruleID, &rule)
+	updateIncapRuleResponse, err := client.UpdateIncapRule(context.Background(), siteID, ruleID, &rule, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -344,7 +342,7 @@ func TestClientUpdateIncapRuleInvalidRule(t *testing.T) {
 		Filter: "Full-URL == \"/someurl\"",
 	}
 
-	endpoint := fmt.Sprintf("/sites/%s/rules/%d?api_id=%s&api_key=%s", siteID, ruleID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/rules/%d", siteID, ruleID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.WriteHeader(404)
@@ -358,7 +356,7 @@ func TestClientUpdateIncapRuleInvalidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	updateIncapRuleResponse, err := client.UpdateIncapRule(siteID, ruleID, &rule)
+	updateIncapRuleResponse, err := client.UpdateIncapRule(context.Background(), siteID, ruleID, &rule, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -382,7 +380,7 @@ func TestClientUpdateIncapRuleValidRule(t *testing.T) {
 		Filter: "Full-URL == \"/someurl\"",
 	}
 
-	endpoint := fmt.Sprintf("/sites/%s/rules/%d?api_id=%s&api_key=%s", siteID, ruleID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/rules/%d", siteID, ruleID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.WriteHeader(200)
@@ -396,7 +394,7 @@ func TestClientUpdateIncapRuleValidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	updateIncapRuleResponse, err := client.UpdateIncapRule(siteID, ruleID, &rule)
+	updateIncapRuleResponse, err := client.UpdateIncapRule(context.Background(), siteID, ruleID, &rule, 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -418,7 +416,7 @@ func TestClientDeleteIncapRuleBadConnection(t *testing.T) {
 	siteID := "42"
 	ruleID := 62
 
-	err := client.DeleteIncapRule(siteID, ruleID)
+	err := client.DeleteIncapRule(context.Background(), siteID, ruleID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -433,7 +431,7 @@ func TestClientDeleteIncapRuleInvalidRule(t *testing.T) {
 	siteID := "42"
 	ruleID := 11111
 
-	endpoint := fmt.Sprintf("/sites/%s/rules/%d?api_id=%s&api_key=%s", siteID, ruleID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/rules/%d", siteID, ruleID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.WriteHeader(404)
@@ -446,7 +444,7 @@ func TestClientDeleteIncapRuleInvalidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.DeleteIncapRule(siteID, ruleID)
+	err := client.DeleteIncapRule(context.Background(), siteID, ruleID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -461,7 +459,7 @@ func TestClientDeleteIncapRuleValidRule(t *testing.T) {
 	siteID := "42"
 	ruleID := 290109
 
-	endpoint := fmt.Sprintf("/sites/%s/rules/%d?api_id=%s&api_key=%s", siteID, ruleID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/rules/%d", siteID, ruleID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.WriteHeader(200)
@@ -474,7 +472,39 @@ func TestClientDeleteIncapRuleValidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.DeleteIncapRule(siteID, ruleID)
+	err := client.DeleteIncapRule(context.Background(), siteID, ruleID, 0)
+	if err != nil {
+		t.Errorf("Should not have received an error")
+	}
+}
+
+func TestClientDeleteIncapRuleAccountID(t *testing.T) {
+	apiID := "foo"
+	apiKey := "bar"
+	siteID := "42"
+	ruleID := 290109
+
+	endpoint := fmt.Sprintf("/sites/%s/rules/%d?caid=1234", siteID, ruleID)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(200)
+		if req.URL.String() != endpoint {
+			t.Errorf("Should have have hit %s endpoint. Got: %s", endpoint, req.URL.String())
+		}
+	}))
+	defer server.Close()
+
+	// The provider account is used unless the resource specifies one
+	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL, AccountID: 5678}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	err := client.DeleteIncapRule(context.Background(), siteID, ruleID, 1234)
+	if err != nil {
+		t.Errorf("Should not have received an error")
+	}
+
+	config.AccountID = 1234
+	err = client.DeleteIncapRule(context.Background(), siteID, ruleID, 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -11,10 +12,10 @@ import (
 const endpointSiteLogLevel = "sites/setlog"
 
 // UpdateLogLevel will update the site log level
-func (c *Client) UpdateLogLevel(siteID, logLevel string) error {
+func (c *Client) UpdateLogLevel(ctx context.Context, siteID, logLevel string, accountID int) error {
 	type LogLevelResponse struct {
-		Res        int    `json:"res"`
-		ResMessage string `json:"res_message"`
+		Res        ResCode `json:"res"`
+		ResMessage string  `json:"res_message"`
 		DebugInfo  struct {
 			LogLevel string `json:"log_level"`
 		} `json:"debug_info"`
@@ -22,13 +23,14 @@ func (c *Client) UpdateLogLevel(siteID, logLevel string) error {
 
 	log.Printf("[INFO] Updating Incapsula log level (%s) for siteID: %s\n", logLevel, siteID)
 
-	// Post form to Incapsula
-	resp, err := c.httpClient.PostForm(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointSiteLogLevel), url.Values{
-		"api_id":    {c.config.APIID},
-		"api_key":   {c.config.APIKey},
+	values := url.Values{
 		"site_id":   {siteID},
 		"log_level": {logLevel},
-	})
+	}
+	c.setAccountID(values, accountID)
+
+	// Post form to Incapsula
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointSiteLogLevel), values, true)
 	if err != nil {
 		return fmt.Errorf("Error updating log level (%s) on site_id: %s: %s", logLevel, siteID, err)
 	}
@@ -49,7 +51,7 @@ func (c *Client) UpdateLogLevel(siteID, logLevel string) error {
 
 	// Look at the response status code from Incapsula
 	if logLevelResponse.Res != 0 {
-		return fmt.Errorf("Error from Incapsula service when updating log level for siteID %s: %s", siteID, string(responseBody))
+		return newAPIError(resp, responseBody, "Error from Incapsula service when updating log level for siteID %s", siteID)
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -18,7 +19,7 @@ func TestClientUpdateLogLevelBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := "42"
 	logLevel := "full"
-	err := client.UpdateLogLevel(siteID, logLevel)
+	err := client.UpdateLogLevel(context.Background(), siteID, logLevel, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -40,7 +41,7 @@ func TestClientUpdateLogLevelBadJSON(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "42"
 	logLevel := "full"
-	err := client.UpdateLogLevel(siteID, logLevel)
+	err := client.UpdateLogLevel(context.Background(), siteID, logLevel, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -62,7 +63,7 @@ func TestClientUpdateLogLevelInvalidSite(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "42"
 	logLevel := "full"
-	err := client.UpdateLogLevel(siteID, logLevel)
+	err := client.UpdateLogLevel(context.Background(), siteID, logLevel, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -84,7 +85,24 @@ func TestClientUpdateLogLevelValidSite(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "42"
 	logLevel := "full"
-	err := client.UpdateLogLevel(siteID, logLevel)
+	err := client.UpdateLogLevel(context.Background(), siteID, logLevel, 0)
+	if err != nil {
+		t.Errorf("Should not have received an error")
+	}
+}
+
+func TestClientUpdateLogLevelAccountID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if accountID := req.FormValue("account_id"); accountID != "5678" {
+			t.Errorf("Should have sent the account_id override, got: %s", accountID)
+		}
+		rw.Write([]byte(`{"res":0}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL, AccountID: 1234}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	err := client.UpdateLogLevel(context.Background(), "42", "full", 5678)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
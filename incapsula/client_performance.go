@@ -1,14 +1,17 @@
 package incapsula
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
 )
 
+const endpointPerformanceAdvanced = "sites/performance/advanced"
+
 // PerformanceSettings is a struct that encompasses all the properties for performance settings
 type PerformanceSettings struct {
 	Mode struct {
@@ -51,13 +54,13 @@ type PerformanceSettings struct {
 }
 
 // GetPerformanceSettings gets the site performance settings
-func (c *Client) GetPerformanceSettings(siteID string) (*PerformanceSettings, int, error) {
+func (c *Client) GetPerformanceSettings(ctx context.Context, siteID string, accountID int) (*PerformanceSettings, error) {
 	log.Printf("[INFO] Getting Incapsula Performance Settings for Site ID %s\n", siteID)
 
 	// Post form to Incapsula
-	resp, err := c.httpClient.Get(fmt.Sprintf("%s/sites/%s/settings/cache?api_id=%s&api_key=%s", c.config.BaseURLRev2, siteID, c.config.APIID, c.config.APIKey))
+	resp, err := c.doJSONRequest(ctx, http.MethodGet, c.withAccountID(fmt.Sprintf("%s/sites/%s/settings/cache", c.config.BaseURLRev2, siteID), accountID), nil)
 	if err != nil {
-		return nil, 0, fmt.Errorf("Error from Incapsula service when reading Incap Performance Settings for Site ID %s: %s", siteID, err)
+		return nil, fmt.Errorf("Error from Incapsula service when reading Incap Performance Settings for Site ID %s: %s", siteID, err)
 	}
 
 	// Read the body
@@ -69,21 +72,21 @@ func (c *Client) GetPerformanceSettings(siteID string) (*PerformanceSettings, in
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, resp.StatusCode, fmt.Errorf("Error status code %d from Incapsula service when reading Incap Performance Settings for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, "Error status code %d from Incapsula service when reading Incap Performance Settings for Site ID %s", resp.StatusCode, siteID)
 	}
 
 	// Parse the JSON
 	var performanceSettings PerformanceSettings
 	err = json.Unmarshal([]byte(responseBody), &performanceSettings)
 	if err != nil {
-		return nil, resp.StatusCode, fmt.Errorf("Error parsing Incap Performance Settings JSON response for Site ID %s: %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Incap Performance Settings JSON response for Site ID %s: %s\nresponse: %s", siteID, err, redactSecrets(string(responseBody)))
 	}
 
-	return &performanceSettings, resp.StatusCode, nil
+	return &performanceSettings, nil
 }
 
 // UpdatePerformanceSettings updates the site performance settings
-func (c *Client) UpdatePerformanceSettings(siteID string, performanceSettings *PerformanceSettings) (*PerformanceSettings, error) {
+func (c *Client) UpdatePerformanceSettings(ctx context.Context, siteID string, performanceSettings *PerformanceSettings, accountID int) (*PerformanceSettings, error) {
 	log.Printf("[INFO] Updating Incapsula Performance Settings for Site ID %s\n", siteID)
 
 	performanceSettingsJSON, err := json.Marshal(performanceSettings)
@@ -93,14 +96,11 @@ func (c *Client) UpdatePerformanceSettings(siteID string, performanceSettings *P
 
 	// Post request to Incapsula
 	log.Printf("[DEBUG] Incapsula Update Incap Performance Settings JSON request: %s\n", string(performanceSettingsJSON))
-	req, err := http.NewRequest(
+	resp, err := c.doJSONRequest(
+		ctx,
 		http.MethodPut,
-		fmt.Sprintf("%s/sites/%s/settings/cache?api_id=%s&api_key=%s", c.config.BaseURLRev2, siteID, c.config.APIID, c.config.APIKey),
-		bytes.NewReader(performanceSettingsJSON))
-	if err != nil {
-		return nil, fmt.Errorf("Error preparing HTTP POST for updating Incap Performance Settings for Site ID %s: %s", siteID, err)
-	}
-	resp, err := c.httpClient.Do(req)
+		c.withAccountID(fmt.Sprintf("%s/sites/%s/settings/cache", c.config.BaseURLRev2, siteID), accountID),
+		performanceSettingsJSON)
 	if err != nil {
 		return nil, fmt.Errorf("Error from Incapsula service when updating Incap Performance Settings for Site ID %s: %s", siteID, err)
 	}
@@ -114,15 +114,59 @@ func (c *Client) UpdatePerformanceSettings(siteID string, performanceSettings *P
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when updating Incap Performance Settings for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, "Error status code %d from Incapsula service when updating Incap Performance Settings for Site ID %s", resp.StatusCode, siteID)
 	}
 
 	// Parse the JSON
 	var updatedPerformanceSettings PerformanceSettings
 	err = json.Unmarshal([]byte(responseBody), &updatedPerformanceSettings)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Incap Performance Settings JSON response for Site ID %s: %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Incap Performance Settings JSON response for Site ID %s: %s\nresponse: %s", siteID, err, redactSecrets(string(responseBody)))
 	}
 
 	return &updatedPerformanceSettings, nil
 }
+
+// UpdatePerformanceAdvancedSetting updates a single advanced performance setting (e.g. minify_javascript) of the site
+func (c *Client) UpdatePerformanceAdvancedSetting(ctx context.Context, siteID, param, value string, accountID int) error {
+	type PerformanceAdvancedResponse struct {
+		Res        ResCode `json:"res"`
+		ResMessage string  `json:"res_message"`
+	}
+
+	log.Printf("[INFO] Updating Incapsula advanced performance setting (%s) with value (%s) for siteID: %s\n", param, value, siteID)
+
+	values := url.Values{
+		"site_id": {siteID},
+		"param":   {param},
+		"value":   {value},
+	}
+	c.setAccountID(values, accountID)
+
+	// Post form to Incapsula
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointPerformanceAdvanced), values, true)
+	if err != nil {
+		return fmt.Errorf("Error updating advanced performance setting (%s) with value (%s) on site_id: %s: %s", param, value, siteID, err)
+	}
+
+	// Read the body
+	defer resp.Body.Close()
+	responseBody, err := ioutil.ReadAll(resp.Body)
+
+	// Dump JSON
+	log.Printf("[DEBUG] Incapsula update advanced performance setting JSON response: %s\n", string(responseBody))
+
+	// Parse the JSON
+	var performanceAdvancedResponse PerformanceAdvancedResponse
+	err = json.Unmarshal([]byte(responseBody), &performanceAdvancedResponse)
+	if err != nil {
+		return fmt.Errorf("Error parsing update advanced performance setting JSON response for siteID %s: %s", siteID, err)
+	}
+
+	// Look at the response status code from Incapsula
+	if performanceAdvancedResponse.Res != 0 {
+		return newAPIError(resp, responseBody, "Error from Incapsula service when updating advanced performance setting (%s) for siteID %s", param, siteID)
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -17,7 +18,7 @@ func TestClientGetPerformanceSettingsBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := "123"
-	performanceSettings, _, err := client.GetPerformanceSettings(siteID)
+	performanceSettings, err := client.GetPerformanceSettings(context.Background(), siteID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -34,7 +35,7 @@ func TestClientGetPerformanceSettingsBadJSON(t *testing.T) {
 	apiKey := "bar"
 	siteID := "42"
 
-	endpoint := fmt.Sprintf("/sites/%s/settings/cache?api_id=%s&api_key=%s", siteID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/settings/cache", siteID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		if req.URL.String() != endpoint {
@@ -47,7 +48,7 @@ func TestClientGetPerformanceSettingsBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	performanceSettings, _, err := client.GetPerformanceSettings(siteID)
+	performanceSettings, err := client.GetPerformanceSettings(context.Background(), siteID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -64,7 +65,7 @@ func TestClientGetPerformanceSettingsInvalidSite(t *testing.T) {
 	apiKey := "bar"
 	siteID := "42"
 
-	endpoint := fmt.Sprintf("/sites/%s/settings/cache?api_id=%s&api_key=%s", siteID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/settings/cache", siteID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.WriteHeader(404)
@@ -78,7 +79,7 @@ func TestClientGetPerformanceSettingsInvalidSite(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	performanceSettings, _, err := client.GetPerformanceSettings(siteID)
+	performanceSettings, err := client.GetPerformanceSettings(context.Background(), siteID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -95,7 +96,7 @@ func TestClientGetPerformanceSettingsValidSite(t *testing.T) {
 	apiKey := "bar"
 	siteID := "42"
 
-	endpoint := fmt.Sprintf("/sites/%s/settings/cache?api_id=%s&api_key=%s", siteID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/settings/cache", siteID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		if req.URL.String() != endpoint {
@@ -108,7 +109,7 @@ func TestClientGetPerformanceSettingsValidSite(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	performanceSettings, _, err := client.GetPerformanceSettings(siteID)
+	performanceSettings, err := client.GetPerformanceSettings(context.Background(), siteID, 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -120,6 +121,26 @@ func TestClientGetPerformanceSettingsValidSite(t *testing.T) {
 	}
 }
 
+func TestClientGetPerformanceSettingsAccountID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/sites/42/settings/cache" {
+			t.Errorf("Should have have hit /sites/42/settings/cache endpoint. Got: %s", req.URL.Path)
+		}
+		if caid := req.URL.Query().Get("caid"); caid != "1234" {
+			t.Errorf("Should have sent the provider account_id as caid, got: %s", caid)
+		}
+		rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURLRev2: server.URL, AccountID: 1234}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	_, err := client.GetPerformanceSettings(context.Background(), "42", 0)
+	if err != nil {
+		t.Errorf("Should not have received an error")
+	}
+}
+
 ////////////////////////////////////////////////////////////////
 // UpdatePerformanceSettings Tests
 ////////////////////////////////////////////////////////////////
@@ -130,7 +151,7 @@ func TestClientUpdatePerformanceSettingsSettingsBadConnection(t *testing.T) {
 	siteID := "123"
 	performanceSettings := PerformanceSettings{}
 	performanceSettings.Mode.HTTPS = "include_all_resources"
-	_, err := client.UpdatePerformanceSettings(siteID, &performanceSettings)
+	_, err := client.UpdatePerformanceSettings(context.Background(), siteID, &performanceSettings, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -146,7 +167,7 @@ func TestClientUpdatePerformanceSettingsInvalidSite(t *testing.T) {
 	performanceSettings := PerformanceSettings{}
 	performanceSettings.Mode.HTTPS = "include_all_resources"
 
-	endpoint := fmt.Sprintf("/sites/%s/settings/cache?api_id=%s&api_key=%s", siteID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/settings/cache", siteID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.WriteHeader(404)
@@ -160,7 +181,7 @@ func TestClientUpdatePerformanceSettingsInvalidSite(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	_, err := client.UpdatePerformanceSettings(siteID, &performanceSettings)
+	_, err := client.UpdatePerformanceSettings(context.Background(), siteID, &performanceSettings, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -176,7 +197,7 @@ func TestClientUpdatePerformanceSettingsValidSite(t *testing.T) {
 	performanceSettings := PerformanceSettings{}
 	performanceSettings.Mode.HTTPS = "include_all_resources"
 
-	endpoint := fmt.Sprintf("/sites/%s/settings/cache?api_id=%s&api_key=%s", siteID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/settings/cache", siteID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		if req.URL.String() != endpoint {
@@ -189,8 +210,81 @@ func TestClientUpdatePerformanceSettingsValidSite(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	_, err := client.UpdatePerformanceSettings(siteID, &performanceSettings)
+	_, err := client.UpdatePerformanceSettings(context.Background(), siteID, &performanceSettings, 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
 }
+
+////////////////////////////////////////////////////////////////
+// UpdatePerformanceAdvancedSetting Tests
+////////////////////////////////////////////////////////////////
+
+func TestClientUpdatePerformanceAdvancedSettingBadConnection(t *testing.T) {
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
+	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
+	err := client.UpdatePerformanceAdvancedSetting(context.Background(), "42", "minify_css", "true", 0)
+	if err == nil {
+		t.Errorf("Should have received an error")
+	}
+	if !strings.HasPrefix(err.Error(), "Error updating advanced performance setting (minify_css) with value (true) on site_id: 42") {
+		t.Errorf("Should have received an client error, got: %s", err)
+	}
+}
+
+func TestClientUpdatePerformanceAdvancedSettingBadJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.String() != fmt.Sprintf("/%s", endpointPerformanceAdvanced) {
+			t.Errorf("Should have have hit /%s endpoint. Got: %s", endpointPerformanceAdvanced, req.URL.String())
+		}
+		rw.Write([]byte(`{`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	err := client.UpdatePerformanceAdvancedSetting(context.Background(), "42", "minify_css", "true", 0)
+	if err == nil {
+		t.Errorf("Should have received an error")
+	}
+	if !strings.HasPrefix(err.Error(), "Error parsing update advanced performance setting JSON response for siteID 42") {
+		t.Errorf("Should have received a JSON parse error, got: %s", err)
+	}
+}
+
+func TestClientUpdatePerformanceAdvancedSettingInvalidSite(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"res":9413,"res_message":"Unknown/unauthorized site_id","debug_info":{"id-info":"13008"}}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	err := client.UpdatePerformanceAdvancedSetting(context.Background(), "42", "minify_css", "true", 0)
+	if err == nil {
+		t.Errorf("Should have received an error")
+	}
+	if !strings.HasPrefix(err.Error(), "Error from Incapsula service when updating advanced performance setting (minify_css) for siteID 42") {
+		t.Errorf("Should have received a bad site error, got: %s", err)
+	}
+}
+
+func TestClientUpdatePerformanceAdvancedSettingValidSite(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.String() != fmt.Sprintf("/%s", endpointPerformanceAdvanced) {
+			t.Errorf("Should have have hit /%s endpoint. Got: %s", endpointPerformanceAdvanced, req.URL.String())
+		}
+		if req.FormValue("site_id") != "42" || req.FormValue("param") != "minify_css" || req.FormValue("value") != "true" {
+			t.Errorf("Should have sent the setting, got: %s", req.Form.Encode())
+		}
+		rw.Write([]byte(`{"res":0,"res_message":"OK"}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	err := client.UpdatePerformanceAdvancedSetting(context.Background(), "42", "minify_css", "true", 0)
+	if err != nil {
+		t.Errorf("Should not have received an error, got: %s", err)
+	}
+}
@@ -1,7 +1,7 @@
 package incapsula
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -70,20 +70,25 @@ type PolicySetting struct {
 }
 
 // AddPolicy adds a policy to be managed by Incapsula
-func (c *Client) AddPolicy(policySubmitted *PolicySubmitted) (*PolicyExtended, error) {
+func (c *Client) AddPolicy(ctx context.Context, policySubmitted *PolicySubmitted) (*PolicyExtended, error) {
 	log.Printf("[INFO] Adding Incapsula Policy\n")
 
-	policyJSON, err := json.Marshal(policySubmitted)
+	// The policy is created in the provider account unless the policy specifies one
+	policy := *policySubmitted
+	policy.AccountID = c.accountID(policy.AccountID)
+
+	policyJSON, err := json.Marshal(policy)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to JSON marshal IncapRule: %s", err)
 	}
 
 	// Post form to Incapsula
 	log.Printf("[DEBUG] Incapsula Add Incap Policy JSON request: %s\n", string(policyJSON))
-	resp, err := c.httpClient.Post(
-		fmt.Sprintf("%s/policies/v2/policies?api_id=%s&api_key=%s", c.config.BaseURLAPI, c.config.APIID, c.config.APIKey),
-		"application/json",
-		bytes.NewReader(policyJSON))
+	resp, err := c.doJSONRequest(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("%s/policies/v2/policies", c.config.BaseURLAPI),
+		policyJSON)
 	if err != nil {
 		return nil, fmt.Errorf("Error from Incapsula service when adding Policy: %s", err)
 	}
@@ -97,25 +102,25 @@ func (c *Client) AddPolicy(policySubmitted *PolicySubmitted) (*PolicyExtended, e
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when adding Policy: %s", resp.StatusCode, string(responseBody))
+		return nil, newAPIError(resp, responseBody, "Error status code %d from Incapsula service when adding Policy", resp.StatusCode)
 	}
 
 	// Parse the JSON
 	var policyExtended PolicyExtended
 	err = json.Unmarshal([]byte(responseBody), &policyExtended)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Policy JSON response: %s\nresponse: %s", err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Policy JSON response: %s\nresponse: %s", err, redactSecrets(string(responseBody)))
 	}
 
 	return &policyExtended, nil
 }
 
 // GetPolicy gets the policy
-func (c *Client) GetPolicy(policyID string) (*PolicyExtended, error) {
+func (c *Client) GetPolicy(ctx context.Context, policyID string, accountID int) (*PolicyExtended, error) {
 	log.Printf("[INFO] Getting Incapsula Policy: %s\n", policyID)
 
 	// Post form to Incapsula
-	resp, err := c.httpClient.Get(fmt.Sprintf("%s/policies/v2/policies/%s?extended=true&api_id=%s&api_key=%s", c.config.BaseURLAPI, policyID, c.config.APIID, c.config.APIKey))
+	resp, err := c.doJSONRequest(ctx, http.MethodGet, c.withAccountID(fmt.Sprintf("%s/policies/v2/policies/%s?extended=true", c.config.BaseURLAPI, policyID), accountID), nil)
 	if err != nil {
 		return nil, fmt.Errorf("Error from Incapsula service when reading Policy for ID %s: %s", policyID, err)
 	}
@@ -129,21 +134,21 @@ func (c *Client) GetPolicy(policyID string) (*PolicyExtended, error) {
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when reading Policy for ID %s: %s", resp.StatusCode, policyID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, "Error status code %d from Incapsula service when reading Policy for ID %s", resp.StatusCode, policyID)
 	}
 
 	// Parse the JSON
 	var policyExtended PolicyExtended
 	err = json.Unmarshal([]byte(responseBody), &policyExtended)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Policy JSON response for Policy ID %s: %s\nresponse: %s", policyID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Policy JSON response for Policy ID %s: %s\nresponse: %s", policyID, err, redactSecrets(string(responseBody)))
 	}
 
 	return &policyExtended, nil
 }
 
 // UpdatePolicy updates the Incapsula Policy
-func (c *Client) UpdatePolicy(policyID int, policySubmitted *PolicySubmitted) (*PolicyExtended, error) {
+func (c *Client) UpdatePolicy(ctx context.Context, policyID int, policySubmitted *PolicySubmitted, accountID int) (*PolicyExtended, error) {
 	log.Printf("[INFO] Updating Incapsula Policy with ID %d\n", policyID)
 
 	policyJSON, err := json.Marshal(policySubmitted)
@@ -153,15 +158,11 @@ func (c *Client) UpdatePolicy(policyID int, policySubmitted *PolicySubmitted) (*
 
 	// Post form to Incapsula
 	log.Printf("[DEBUG] Incapsula Update Incap Policy JSON request: %s\n", string(policyJSON))
-	req, err := http.NewRequest(
+	resp, err := c.doJSONRequest(
+		ctx,
 		http.MethodPut,
-		fmt.Sprintf("%s/policies/v2/policies/%d?api_id=%s&api_key=%s", c.config.BaseURLAPI, policyID, c.config.APIID, c.config.APIKey),
-		bytes.NewReader(policyJSON))
-	if err != nil {
-		return nil, fmt.Errorf("Error preparing HTTP PUT for updating Incap Policy with ID %d: %s", policyID, err)
-	}
-	req.Header.Set("Content-Type", "application/json; charset=utf-8")
-	resp, err := c.httpClient.Do(req)
+		c.withAccountID(fmt.Sprintf("%s/policies/v2/policies/%d", c.config.BaseURLAPI, policyID), accountID),
+		policyJSON)
 	if err != nil {
 		return nil, fmt.Errorf("Error from Incapsula service when updating Policy: %s", err)
 	}
@@ -175,32 +176,29 @@ func (c *Client) UpdatePolicy(policyID int, policySubmitted *PolicySubmitted) (*
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when updating Policy with ID %d: %s", resp.StatusCode, policyID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, "Error status code %d from Incapsula service when updating Policy with ID %d", resp.StatusCode, policyID)
 	}
 
 	// Parse the JSON
 	var updatedPolicyExtended PolicyExtended
 	err = json.Unmarshal([]byte(responseBody), &updatedPolicyExtended)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Policy JSON response for Policy ID %d: %s\nresponse: %s", policyID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Policy JSON response for Policy ID %d: %s\nresponse: %s", policyID, err, redactSecrets(string(responseBody)))
 	}
 
 	return &updatedPolicyExtended, nil
 }
 
 // DeletePolicy deletes a policy currently managed by Incapsula
-func (c *Client) DeletePolicy(policyID string) error {
+func (c *Client) DeletePolicy(ctx context.Context, policyID string, accountID int) error {
 	log.Printf("[INFO] Deleting Incapsula Policy for ID %s\n", policyID)
 
 	// Delete request to Incapsula
-	req, err := http.NewRequest(
+	resp, err := c.doJSONRequest(
+		ctx,
 		http.MethodDelete,
-		fmt.Sprintf("%s/policies/v2/policies/%s?api_id=%s&api_key=%s", c.config.BaseURLAPI, policyID, c.config.APIID, c.config.APIKey),
+		c.withAccountID(fmt.Sprintf("%s/policies/v2/policies/%s", c.config.BaseURLAPI, policyID), accountID),
 		nil)
-	if err != nil {
-		return fmt.Errorf("Error preparing HTTP DELETE for deleting Policy with ID %s: %s", policyID, err)
-	}
-	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("Error from Incapsula service when deleting Policy with ID %s: %s", policyID, err)
 	}
@@ -214,7 +212,7 @@ func (c *Client) DeletePolicy(policyID string) error {
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from Incapsula service when deleting Policy with ID %s: %s", resp.StatusCode, policyID, string(responseBody))
+		return newAPIError(resp, responseBody, "Error status code %d from Incapsula service when deleting Policy with ID %s", resp.StatusCode, policyID)
 	}
 
 	return nil
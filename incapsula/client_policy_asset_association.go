@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -8,13 +9,14 @@ import (
 )
 
 // AddPolicyAssetAssociation adds a policy to be managed by Incapsula
-func (c *Client) AddPolicyAssetAssociation(policyID, assetID, assetType string) error {
+func (c *Client) AddPolicyAssetAssociation(ctx context.Context, policyID, assetID, assetType string, accountID int) error {
 	log.Printf("[INFO] Adding Incapsula Policy Asset Association: %s-%s-%s\n", policyID, assetID, assetType)
 
 	// Post form to Incapsula
-	resp, err := c.httpClient.Post(
-		fmt.Sprintf("%s/policies/v2/assets/%s/%s/policies/%s?api_id=%s&api_key=%s", c.config.BaseURLAPI, assetType, assetID, policyID, c.config.APIID, c.config.APIKey),
-		"application/json",
+	resp, err := c.doJSONRequest(
+		ctx,
+		http.MethodPost,
+		c.withAccountID(fmt.Sprintf("%s/policies/v2/assets/%s/%s/policies/%s", c.config.BaseURLAPI, assetType, assetID, policyID), accountID),
 		nil)
 	if err != nil {
 		return fmt.Errorf("Error from Incapsula service when adding Policy Asset Association: %s", err)
@@ -29,25 +31,22 @@ func (c *Client) AddPolicyAssetAssociation(policyID, assetID, assetType string)
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from Incapsula service when adding Policy Asset Association: %s", resp.StatusCode, string(responseBody))
+		return newAPIError(resp, responseBody, "Error status code %d from Incapsula service when adding Policy Asset Association", resp.StatusCode)
 	}
 
 	return nil
 }
 
 // DeletePolicyAssetAssociation deletes a policy asset association currently managed by Incapsula
-func (c *Client) DeletePolicyAssetAssociation(policyID, assetID, assetType string) error {
+func (c *Client) DeletePolicyAssetAssociation(ctx context.Context, policyID, assetID, assetType string, accountID int) error {
 	log.Printf("[INFO] Deleting Incapsula Policy Asset Association: %s-%s-%s\n", policyID, assetID, assetType)
 
 	// Delete request to Incapsula
-	req, err := http.NewRequest(
+	resp, err := c.doJSONRequest(
+		ctx,
 		http.MethodDelete,
-		fmt.Sprintf("%s/policies/v2/assets/%s/%s/policies/%s?api_id=%s&api_key=%s", c.config.BaseURLAPI, assetType, assetID, policyID, c.config.APIID, c.config.APIKey),
+		c.withAccountID(fmt.Sprintf("%s/policies/v2/assets/%s/%s/policies/%s", c.config.BaseURLAPI, assetType, assetID, policyID), accountID),
 		nil)
-	if err != nil {
-		return fmt.Errorf("Error preparing HTTP DELETE for deleting Policy Asset Association: %s", err)
-	}
-	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("Error from Incapsula service when deleting Policy Asset Association (%s): %s", policyID, err)
 	}
@@ -61,7 +60,7 @@ func (c *Client) DeletePolicyAssetAssociation(policyID, assetID, assetType strin
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from Incapsula service when deleting Policy Asset Association: %s", resp.StatusCode, string(responseBody))
+		return newAPIError(resp, responseBody, "Error status code %d from Incapsula service when deleting Policy Asset Association", resp.StatusCode)
 	}
 
 	return nil
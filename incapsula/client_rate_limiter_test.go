@@ -0,0 +1,88 @@
+package incapsula
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////
+// Rate Limiter Tests
+////////////////////////////////////////////////////////////////
+
+func TestRateLimiterWaitThrottles(t *testing.T) {
+	limiter := newRateLimiter(20)
+	start := time.Now()
+	for i := 0; i < 25; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("Should not have received an error, got: %s", err)
+		}
+	}
+
+	// 20 tokens are available up front, the remaining 5 are refilled at 20 per second
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("Should have been throttled for at least 200ms, got: %s", elapsed)
+	}
+}
+
+func TestRateLimiterWaitCancelled(t *testing.T) {
+	limiter := newRateLimiter(1)
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx); err == nil {
+		t.Errorf("Should have received an error when the context is done")
+	}
+}
+
+////////////////////////////////////////////////////////////////
+// Concurrency Cap Tests
+////////////////////////////////////////////////////////////////
+
+func TestClientMaxConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{APIID: "foo", APIKey: "bar", MaxConcurrentRequests: 2})
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.doJSONRequest(context.Background(), http.MethodGet, server.URL, nil)
+			if err != nil {
+				t.Errorf("Should not have received an error, got: %s", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > 2 {
+		t.Errorf("Should have had at most 2 requests in flight, got: %d", maxInFlight)
+	}
+}
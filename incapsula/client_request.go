@@ -0,0 +1,228 @@
+package incapsula
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Bounds of the exponential backoff between two attempts of the same request
+const retryWaitMin = 1 * time.Second
+const retryWaitMax = 30 * time.Second
+
+// postForm sends a form encoded POST request (APIv1) through the shared request pipeline
+// Most APIv1 operations are POSTs, so the caller decides whether the operation is safe to repeat
+func (c *Client) postForm(ctx context.Context, rawURL string, values url.Values, idempotent bool) (*http.Response, error) {
+	if err := c.checkCredentials(ctx); err != nil {
+		return nil, err
+	}
+
+	return c.sendForm(ctx, rawURL, values, idempotent)
+}
+
+// sendForm is postForm without the deferred credentials check
+func (c *Client) sendForm(ctx context.Context, rawURL string, values url.Values, idempotent bool) (*http.Response, error) {
+	// APIv1 still requires the credentials as parameters
+	// They are only ever sent in the form body, never in the URL
+	form := url.Values{
+		"api_id":  {c.config.APIID},
+		"api_key": {c.config.APIKey},
+	}
+	for key, value := range values {
+		form[key] = value
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rawURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return c.doRequest(req, idempotent)
+}
+
+// doJSONRequest sends a request with an optional JSON body (APIv2 and API) through the shared request pipeline
+// GET, PUT and DELETE requests are considered idempotent, POST requests are not
+func (c *Client) doJSONRequest(ctx context.Context, method, rawURL string, data []byte) (*http.Response, error) {
+	if err := c.checkCredentials(ctx); err != nil {
+		return nil, err
+	}
+
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, body)
+	if err != nil {
+		return nil, err
+	}
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return c.doRequest(req, method != http.MethodPost)
+}
+
+// doRequest is the single place where requests are sent to Incapsula
+// Transport errors and 5xx responses of idempotent requests are retried, as are throttled (429) requests
+// The caller is responsible for closing the body of the returned response
+func (c *Client) doRequest(req *http.Request, idempotent bool) (*http.Response, error) {
+	if c.config.UserAgent != "" {
+		req.Header.Set("User-Agent", c.config.UserAgent)
+	}
+	c.authenticate(req)
+
+	maxAttempts := c.config.RetryMaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var waited time.Duration
+
+	for attempt := 1; ; attempt++ {
+		// The body has been consumed by the previous attempt
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := c.send(req)
+
+		if attempt >= maxAttempts || req.Context().Err() != nil || !shouldRetry(resp, err, idempotent) {
+			return resp, err
+		}
+
+		wait := retryBackoff(attempt, resp)
+		if waited+wait > c.config.RetryMaxWait {
+			log.Printf("[WARN] Giving up on Incapsula %s request to %s after %d attempt(s): retry wait budget of %s exhausted\n", req.Method, req.URL.Path, attempt, c.config.RetryMaxWait)
+			return resp, err
+		}
+
+		if err != nil {
+			log.Printf("[WARN] Incapsula %s request to %s failed (attempt %d of %d), retrying in %s: %s\n", req.Method, req.URL.Path, attempt, maxAttempts, wait, err)
+		} else {
+			log.Printf("[WARN] Incapsula %s request to %s returned status code %d (attempt %d of %d), retrying in %s\n", req.Method, req.URL.Path, resp.StatusCode, attempt, maxAttempts, wait)
+
+			// Drain the body so the connection can be reused
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		waited += wait
+	}
+}
+
+// authenticate is the single auth hook of the request pipeline
+// Credentials are sent as headers so they never show up in URLs, proxy logs or error messages
+func (c *Client) authenticate(req *http.Request) {
+	req.Header.Set("x-API-Id", c.config.APIID)
+	req.Header.Set("x-API-Key", c.config.APIKey)
+}
+
+// send waits for the rate limiter and a free request slot before handing a single attempt to the HTTP client
+// The request slot is held until the response body is closed
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.requestSlots == nil {
+		return c.httpClient.Do(req)
+	}
+
+	if err := c.requestSlots.acquire(req.Context()); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.requestSlots.release()
+		return nil, err
+	}
+	resp.Body = &slotReleasingBody{ReadCloser: resp.Body, release: c.requestSlots.release}
+
+	return resp, nil
+}
+
+// shouldRetry reports whether an attempt failed in a way that is worth repeating
+func shouldRetry(resp *http.Response, err error, idempotent bool) bool {
+	if err != nil {
+		return idempotent
+	}
+
+	// Throttled requests have not been processed, so they are always safe to repeat
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	if resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented {
+		return idempotent
+	}
+
+	return false
+}
+
+// retryBackoff computes how long to wait before the next attempt
+// A Retry-After header takes precedence, otherwise the wait doubles on every attempt (with jitter)
+func retryBackoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return wait
+		}
+	}
+
+	backoff := retryWaitMin << uint(attempt-1)
+	if backoff <= 0 || backoff > retryWaitMax {
+		backoff = retryWaitMax
+	}
+
+	// Jitter spreads out the retries of resources that are applied in parallel
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a number of seconds or an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
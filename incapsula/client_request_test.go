@@ -0,0 +1,294 @@
+package incapsula
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////
+// doRequest Tests
+////////////////////////////////////////////////////////////////
+
+func TestClientDoRequestRetriesServerErrors(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		attempts++
+		if attempts < 3 {
+			rw.Header().Set("Retry-After", "0")
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		rw.Write([]byte(`{"res":0}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURLRev2: server.URL, RetryMaxAttempts: 4, RetryMaxWait: time.Minute}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	resp, err := client.doJSONRequest(context.Background(), http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Should have received a 200 status code, got: %d", resp.StatusCode)
+	}
+	if attempts != 3 {
+		t.Errorf("Should have made 3 attempts, got: %d", attempts)
+	}
+}
+
+func TestClientDoRequestStopsAfterMaxAttempts(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		attempts++
+		rw.Header().Set("Retry-After", "0")
+		rw.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", RetryMaxAttempts: 2, RetryMaxWait: time.Minute}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	resp, err := client.doJSONRequest(context.Background(), http.MethodDelete, server.URL, nil)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("Should have received the last 502 status code, got: %d", resp.StatusCode)
+	}
+	if attempts != 2 {
+		t.Errorf("Should have made 2 attempts, got: %d", attempts)
+	}
+}
+
+func TestClientDoRequestDoesNotRetryNonIdempotentServerErrors(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		attempts++
+		rw.Header().Set("Retry-After", "0")
+		rw.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", RetryMaxAttempts: 4, RetryMaxWait: time.Minute}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	resp, err := client.postForm(context.Background(), server.URL, url.Values{"domain": {"www.example.com"}}, false)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	defer resp.Body.Close()
+	if attempts != 1 {
+		t.Errorf("Should have made a single attempt, got: %d", attempts)
+	}
+}
+
+func TestClientDoRequestRetriesThrottledRequestsWithBody(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		attempts++
+		body, _ := ioutil.ReadAll(req.Body)
+		if string(body) != `{"name":"foo"}` {
+			t.Errorf("Should have received the full request body on attempt %d, got: %s", attempts, string(body))
+		}
+		if attempts == 1 {
+			rw.Header().Set("Retry-After", "0")
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		rw.Write([]byte(`{"rule_id":1}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", RetryMaxAttempts: 4, RetryMaxWait: time.Minute}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	resp, err := client.doJSONRequest(context.Background(), http.MethodPost, server.URL, []byte(`{"name":"foo"}`))
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Should have received a 200 status code, got: %d", resp.StatusCode)
+	}
+	if attempts != 2 {
+		t.Errorf("Should have made 2 attempts, got: %d", attempts)
+	}
+}
+
+func TestClientDoRequestRespectsMaxWait(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		attempts++
+		rw.Header().Set("Retry-After", "120")
+		rw.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", RetryMaxAttempts: 4, RetryMaxWait: time.Minute}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	resp, err := client.doJSONRequest(context.Background(), http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Should have received a 429 status code, got: %d", resp.StatusCode)
+	}
+	if attempts != 1 {
+		t.Errorf("Should have given up after a single attempt, got: %d", attempts)
+	}
+}
+
+func TestClientDoRequestCancelledContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		<-req.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	config := &Config{APIID: "foo", APIKey: "bar", RetryMaxAttempts: 4, RetryMaxWait: time.Minute}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	_, err := client.doJSONRequest(ctx, http.MethodGet, server.URL, nil)
+	if err == nil {
+		t.Fatalf("Should have received an error")
+	}
+	if ctx.Err() == nil {
+		t.Errorf("Should have returned once the context was done")
+	}
+}
+
+func TestClientDoRequestCancelledDuringBackoff(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		attempts++
+		rw.Header().Set("Retry-After", "30")
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	config := &Config{APIID: "foo", APIKey: "bar", RetryMaxAttempts: 4, RetryMaxWait: time.Minute}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	start := time.Now()
+	_, err := client.doJSONRequest(ctx, http.MethodGet, server.URL, nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Should have received a deadline exceeded error, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Should not have waited for the full backoff, got: %s", elapsed)
+	}
+	if attempts != 1 {
+		t.Errorf("Should have made a single attempt, got: %d", attempts)
+	}
+}
+
+////////////////////////////////////////////////////////////////
+// Authentication Tests
+////////////////////////////////////////////////////////////////
+
+func TestClientDoJSONRequestSendsCredentialHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("x-API-Id") != "foo" || req.Header.Get("x-API-Key") != "bar" {
+			t.Errorf("Should have received the credentials as headers, got: %v", req.Header)
+		}
+		if req.URL.RawQuery != "" {
+			t.Errorf("Should not have received a query string, got: %s", req.URL.RawQuery)
+		}
+		rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar"}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	resp, err := client.doJSONRequest(context.Background(), http.MethodGet, server.URL+"/sites/42/rules/1", nil)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	resp.Body.Close()
+}
+
+func TestClientPostFormSendsCredentialsInBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("x-API-Id") != "foo" || req.Header.Get("x-API-Key") != "bar" {
+			t.Errorf("Should have received the credentials as headers, got: %v", req.Header)
+		}
+		if req.URL.RawQuery != "" {
+			t.Errorf("Should not have received a query string, got: %s", req.URL.RawQuery)
+		}
+		if req.PostFormValue("api_id") != "foo" || req.PostFormValue("api_key") != "bar" || req.PostFormValue("site_id") != "42" {
+			t.Errorf("Should have received the credentials and parameters in the form body, got: %v", req.PostForm)
+		}
+		rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar"}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	resp, err := client.postForm(context.Background(), server.URL+"/sites/status", url.Values{"site_id": {"42"}}, true)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	resp.Body.Close()
+}
+
+func TestClientDoRequestSendsUserAgent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("User-Agent") != "terraform-provider-incapsula/1.2.3 terraform/0.14.0" {
+			t.Errorf("Should have received the provider User-Agent, got: %s", req.Header.Get("User-Agent"))
+		}
+		rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", UserAgent: "terraform-provider-incapsula/1.2.3 terraform/0.14.0"}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	resp, err := client.postForm(context.Background(), server.URL+"/account", url.Values{}, true)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	resp.Body.Close()
+}
+
+////////////////////////////////////////////////////////////////
+// Backoff Tests
+////////////////////////////////////////////////////////////////
+
+func TestRetryBackoffBounds(t *testing.T) {
+	for attempt := 1; attempt <= 10; attempt++ {
+		wait := retryBackoff(attempt, nil)
+		if wait < retryWaitMin/2 || wait > retryWaitMax {
+			t.Errorf("Backoff for attempt %d should be between %s and %s, got: %s", attempt, retryWaitMin/2, retryWaitMax, wait)
+		}
+	}
+}
+
+func TestRetryBackoffHonorsRetryAfter(t *testing.T) {
+	resp := &http.Response{Header: http.Header{"Retry-After": {"7"}}}
+	if wait := retryBackoff(1, resp); wait != 7*time.Second {
+		t.Errorf("Backoff should match the Retry-After header, got: %s", wait)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if _, ok := parseRetryAfter(""); ok {
+		t.Errorf("Empty Retry-After header should not be parsed")
+	}
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Errorf("Invalid Retry-After header should not be parsed")
+	}
+	if wait, ok := parseRetryAfter("3"); !ok || wait != 3*time.Second {
+		t.Errorf("Retry-After in seconds should be parsed, got: %s", wait)
+	}
+	date := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+	if wait, ok := parseRetryAfter(date); !ok || wait != 0 {
+		t.Errorf("Retry-After date in the past should be parsed as no wait, got: %s", wait)
+	}
+}
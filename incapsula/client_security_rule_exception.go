@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -32,17 +33,15 @@ var securityRuleExceptionParamMapping = map[string][]string{
 
 // SecurityRuleExceptionCreateResponse provides exception_id of rule exception
 type SecurityRuleExceptionCreateResponse struct {
-	Res         string `json:"res"`
-	ExceptionID string `json:"exception_id"`
-	Status      string `json:"status"`
+	Res         ResCode `json:"res"`
+	ExceptionID string  `json:"exception_id"`
+	Status      string  `json:"status"`
 }
 
 // AddSecurityRuleException adds a security rule exception
-func (c *Client) AddSecurityRuleException(siteID int, ruleID, clientAppTypes, clientApps, countries, continents, ips, urlPatterns, urls, userAgents, parameters string) (*SecurityRuleExceptionCreateResponse, error) {
+func (c *Client) AddSecurityRuleException(ctx context.Context, siteID int, ruleID, clientAppTypes, clientApps, countries, continents, ips, urlPatterns, urls, userAgents, parameters string, accountID int) (*SecurityRuleExceptionCreateResponse, error) {
 	// Base URL values
 	values := url.Values{
-		"api_id":            {c.config.APIID},
-		"api_key":           {c.config.APIKey},
 		"site_id":           {strconv.Itoa(siteID)},
 		"rule_id":           {ruleID},
 		"exception_id_only": {"true"},
@@ -79,8 +78,10 @@ func (c *Client) AddSecurityRuleException(siteID int, ruleID, clientAppTypes, cl
 		return nil, fmt.Errorf("Error configuring security rule exception: invalid rule_id (%s)", ruleID)
 	}
 
+	c.setAccountID(values, accountID)
+
 	// Post form to Incapsula
-	resp, err := c.httpClient.PostForm(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointExceptionConfigure), values)
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointExceptionConfigure), values, false)
 	if err != nil {
 		return nil, fmt.Errorf("Error configuring security rule exception rule_id (%s) for site_id (%d)", ruleID, siteID)
 	}
@@ -100,19 +101,17 @@ func (c *Client) AddSecurityRuleException(siteID int, ruleID, clientAppTypes, cl
 	}
 
 	// Look at the response status code from Incapsula
-	if securityRuleExceptionCreateResponse.Res != "0" {
-		return nil, fmt.Errorf("Error from Incapsula service when adding security rule exception for rule_id (%s) and site_id (%d): %s", ruleID, siteID, string(responseBody))
+	if securityRuleExceptionCreateResponse.Res != 0 {
+		return nil, newAPIError(resp, responseBody, "Error from Incapsula service when adding security rule exception for rule_id (%s) and site_id (%d)", ruleID, siteID)
 	}
 
 	return &securityRuleExceptionCreateResponse, nil
 }
 
 // EditSecurityRuleException edits a security rule exception
-func (c *Client) EditSecurityRuleException(siteID int, ruleID, clientAppTypes, clientApps, countries, continents, ips, urlPatterns, urls, userAgents, parameters, whitelistID string) (*SiteStatusResponse, error) {
+func (c *Client) EditSecurityRuleException(ctx context.Context, siteID int, ruleID, clientAppTypes, clientApps, countries, continents, ips, urlPatterns, urls, userAgents, parameters, whitelistID string, accountID int) (*SiteStatusResponse, error) {
 	// Base URL values
 	values := url.Values{
-		"api_id":       {c.config.APIID},
-		"api_key":      {c.config.APIKey},
 		"site_id":      {strconv.Itoa(siteID)},
 		"rule_id":      {ruleID},
 		"whitelist_id": {whitelistID},
@@ -149,8 +148,10 @@ func (c *Client) EditSecurityRuleException(siteID int, ruleID, clientAppTypes, c
 		return nil, fmt.Errorf("Error configuring security rule exception: invalid rule_id (%s)", ruleID)
 	}
 
+	c.setAccountID(values, accountID)
+
 	// Post form to Incapsula
-	resp, err := c.httpClient.PostForm(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointExceptionConfigure), values)
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointExceptionConfigure), values, true)
 	if err != nil {
 		return nil, fmt.Errorf("Error configuring security rule exception rule_id (%s) for site_id (%d)", ruleID, siteID)
 	}
@@ -171,22 +172,23 @@ func (c *Client) EditSecurityRuleException(siteID int, ruleID, clientAppTypes, c
 
 	// Look at the response status code from Incapsula
 	if siteStatusResponse.Res != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when adding security rule exception for rule_id (%s) and site_id (%d): %s", ruleID, siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, "Error from Incapsula service when adding security rule exception for rule_id (%s) and site_id (%d)", ruleID, siteID)
 	}
 
 	return &siteStatusResponse, nil
 }
 
 // ListSecurityRuleExceptions gets the site status including the list of exceptions for security rules
-func (c *Client) ListSecurityRuleExceptions(siteID, ruleID string) (*SiteStatusResponse, error) {
+func (c *Client) ListSecurityRuleExceptions(ctx context.Context, siteID, ruleID string, accountID int) (*SiteStatusResponse, error) {
 	log.Printf("[INFO] Getting Incapsula security rule exeptions for rule_id (%s) on site_id (%s)\n", ruleID, siteID)
 
-	// Post form to Incapsula
-	resp, err := c.httpClient.PostForm(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointExceptionList), url.Values{
-		"api_id":  {c.config.APIID},
-		"api_key": {c.config.APIKey},
+	values := url.Values{
 		"site_id": {siteID},
-	})
+	}
+	c.setAccountID(values, accountID)
+
+	// Post form to Incapsula
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointExceptionList), values, true)
 	if err != nil {
 		return nil, fmt.Errorf("Error getting security rule exceptions for rule_id (%s) on siteID (%s): %s", ruleID, siteID, err)
 	}
@@ -202,47 +204,36 @@ func (c *Client) ListSecurityRuleExceptions(siteID, ruleID string) (*SiteStatusR
 	var siteStatusResponse SiteStatusResponse
 	err = json.Unmarshal([]byte(responseBody), &siteStatusResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing ListSecurityRuleExceptions JSON response for siteID: %s %s\nresponse: %s", siteID, err, string(responseBody))
-	}
-
-	// Res can sometimes oscillate between a string and number
-	// We need to add safeguards for this inside the provider
-	var resString string
-
-	if resNumber, ok := siteStatusResponse.Res.(float64); ok {
-		resString = fmt.Sprintf("%d", int(resNumber))
-	} else {
-		resString = siteStatusResponse.Res.(string)
+		return nil, fmt.Errorf("Error parsing ListSecurityRuleExceptions JSON response for siteID: %s %s\nresponse: %s", siteID, err, redactSecrets(string(responseBody)))
 	}
 
 	// Look at the response status code from Incapsula
-	if resString != "0" {
-		return &siteStatusResponse, fmt.Errorf("Error from Incapsula service when getting security rule exceptions (site_id: %s): %s", siteID, string(responseBody))
+	if siteStatusResponse.Res != 0 {
+		return &siteStatusResponse, newAPIError(resp, responseBody, "Error from Incapsula service when getting security rule exceptions (site_id: %s)", siteID)
 	}
 
 	return &siteStatusResponse, nil
 }
 
 // DeleteSecurityRuleException deletes a security rule exception
-func (c *Client) DeleteSecurityRuleException(siteID int, ruleID, whitelistID string) error {
+func (c *Client) DeleteSecurityRuleException(ctx context.Context, siteID int, ruleID, whitelistID string, accountID int) error {
 	type ExceptionDeleteResponse struct {
-		Res        int    `json:"res"`
-		ResMessage string `json:"res_message"`
-		Status     string `json:"status"`
+		Res        ResCode `json:"res"`
+		ResMessage string  `json:"res_message"`
+		Status     string  `json:"status"`
 	}
 
 	// Base URL values
 	values := url.Values{
-		"api_id":           {c.config.APIID},
-		"api_key":          {c.config.APIKey},
 		"site_id":          {strconv.Itoa(siteID)},
 		"rule_id":          {ruleID},
 		"whitelist_id":     {whitelistID},
 		"delete_whitelist": {"true"},
 	}
+	c.setAccountID(values, accountID)
 
 	// Post form to Incapsula
-	resp, err := c.httpClient.PostForm(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointExceptionConfigure), values)
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointExceptionConfigure), values, true)
 	if err != nil {
 		return fmt.Errorf("Error deleting security rule exception whitelist_id (%s) for rule_id (%s) for site_id (%d)", whitelistID, ruleID, siteID)
 	}
@@ -263,7 +254,7 @@ func (c *Client) DeleteSecurityRuleException(siteID int, ruleID, whitelistID str
 
 	// Look at the response status code from Incapsula
 	if exceptionDeleteResponse.Res != 0 {
-		return fmt.Errorf("Error from Incapsula service when deleting security rule exception for rule_id (%s) and site_id (%d): %s", ruleID, siteID, string(responseBody))
+		return newAPIError(resp, responseBody, "Error from Incapsula service when deleting security rule exception for rule_id (%s) and site_id (%d)", ruleID, siteID)
 	}
 
 	return nil
@@ -1,11 +1,13 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/url"
+	"sort"
 	"strconv"
 )
 
@@ -13,17 +15,24 @@ const endpointSiteAdd = "sites/add"
 const endpointSiteStatus = "sites/status"
 const endpointSiteUpdate = "sites/configure"
 const endpointSiteDelete = "sites/delete"
+const endpointSiteList = "sites/list"
+
+// Maximum page size of the site list
+const siteListPageSize = 100
+
+// Maximum number of site list pages read by ListAllSites (100000 sites)
+var siteListMaxPages = 1000
 
 // SiteAddResponse contains the relevant site information when adding an Incapsula managed site
 type SiteAddResponse struct {
-	SiteID int `json:"site_id"`
-	Res    int `json:"res"`
+	SiteID int     `json:"site_id"`
+	Res    ResCode `json:"res"`
 }
 
 // SiteUpdateResponse contains the relevant site information when updating an Incapsula managed site
 type SiteUpdateResponse struct {
-	SiteID int `json:"site_id"`
-	Res    int `json:"res"`
+	SiteID int     `json:"site_id"`
+	Res    ResCode `json:"res"`
 }
 
 // SiteStatusDNSValidationData is DNS related validation data (HTML is a map[string][]string)
@@ -33,33 +42,40 @@ type SiteStatusDNSValidationData struct {
 	SetDataTo     []string `json:"set_data_to"`
 }
 
+// SiteStatusDNSRecord is a DNS record of the site (or of its original DNS setup)
+type SiteStatusDNSRecord struct {
+	DNSRecordName string   `json:"dns_record_name"`
+	SetTypeTo     string   `json:"set_type_to"`
+	SetDataTo     []string `json:"set_data_to"`
+}
+
+// SiteValidationRecord is a record to publish to validate the generated certificate of the site
+type SiteValidationRecord struct {
+	Name   string
+	Type   string
+	Values []string
+	Method string
+}
+
 // SiteStatusResponse contains managed site information
 type SiteStatusResponse struct {
-	SiteID            int      `json:"site_id"`
-	Status            string   `json:"status"`
-	Domain            string   `json:"domain"`
-	RefID             string   `json:"ref_id,omitempty"`
-	AccountID         int      `json:"account_id"`
-	AccelerationLevel string   `json:"acceleration_level"`
-	SiteCreationDate  int64    `json:"site_creation_date"`
-	Ips               []string `json:"ips"`
-	DNS               []struct {
-		DNSRecordName string   `json:"dns_record_name"`
-		SetTypeTo     string   `json:"set_type_to"`
-		SetDataTo     []string `json:"set_data_to"`
-	} `json:"dns"`
-	OriginalDNS []struct {
-		DNSRecordName string   `json:"dns_record_name"`
-		SetTypeTo     string   `json:"set_type_to"`
-		SetDataTo     []string `json:"set_data_to"`
-	} `json:"original_dns"`
-	Warnings                     []interface{} `json:"warnings"`
-	Active                       string        `json:"active"`
-	SupportAllTLSVersions        bool          `json:"support_all_tls_versions"`
-	WildcardSanForNewSites       bool          `json:"wildcard_san_for_new_sites"`
-	NakedDomainSanForNewWwwSites bool          `json:"naked_domain_san_for_new_www_sites"`
-	AdditionalErrors             []interface{} `json:"additionalErrors"`
-	DisplayName                  string        `json:"display_name"`
+	SiteID                       int                   `json:"site_id"`
+	Status                       string                `json:"status"`
+	Domain                       string                `json:"domain"`
+	RefID                        string                `json:"ref_id,omitempty"`
+	AccountID                    int                   `json:"account_id"`
+	AccelerationLevel            string                `json:"acceleration_level"`
+	SiteCreationDate             int64                 `json:"site_creation_date"`
+	Ips                          []string              `json:"ips"`
+	DNS                          []SiteStatusDNSRecord `json:"dns"`
+	OriginalDNS                  []SiteStatusDNSRecord `json:"original_dns"`
+	Warnings                     []interface{}         `json:"warnings"`
+	Active                       string                `json:"active"`
+	SupportAllTLSVersions        bool                  `json:"support_all_tls_versions"`
+	WildcardSanForNewSites       bool                  `json:"wildcard_san_for_new_sites"`
+	NakedDomainSanForNewWwwSites bool                  `json:"naked_domain_san_for_new_www_sites"`
+	AdditionalErrors             []interface{}         `json:"additionalErrors"`
+	DisplayName                  string                `json:"display_name"`
 	Security                     struct {
 		Waf struct {
 			Rules []struct {
@@ -196,35 +212,30 @@ type SiteStatusResponse struct {
 		Cache300X                 bool          `json:"cache300x"`
 		CacheHeaders              []interface{} `json:"cache_headers"`
 	} `json:"performance_configuration"`
-	ExtendedDdos int         `json:"extended_ddos"`
-	ExceptionID  string      `json:"exception_id,omitempty"`
-	LogLevel     string      `json:"log_level,omitempty"`
-	Res          interface{} `json:"res"`
-	ResMessage   string      `json:"res_message"`
+	ExtendedDdos int     `json:"extended_ddos"`
+	ExceptionID  string  `json:"exception_id,omitempty"`
+	LogLevel     string  `json:"log_level,omitempty"`
+	Res          ResCode `json:"res"`
+	ResMessage   string  `json:"res_message"`
 	DebugInfo    struct {
 		IDInfo string `json:"id-info"`
 	} `json:"debug_info"`
 }
 
 // AddSite adds a site to be managed by Incapsula
-func (c *Client) AddSite(domain, refID, sendSiteSetupEmails, siteIP, forceSSL string, accountID int) (*SiteAddResponse, error) {
+func (c *Client) AddSite(ctx context.Context, domain, refID, sendSiteSetupEmails, siteIP, forceSSL string, accountID int) (*SiteAddResponse, error) {
 	log.Printf("[INFO] Adding Incapsula site for domain: %s (account ID %d)\n", domain, accountID)
 
 	values := url.Values{
-		"api_id":                 {c.config.APIID},
-		"api_key":                {c.config.APIKey},
 		"domain":                 {domain},
 		"ref_id":                 {refID},
 		"send_site_setup_emails": {sendSiteSetupEmails},
 		"site_ip":                {siteIP},
 		"force_ssl":              {forceSSL},
 	}
-	if accountID != 0 {
-		values["account_id"] = make([]string, 1)
-		values["account_id"][0] = fmt.Sprint(accountID)
-	}
+	c.setAccountID(values, accountID)
 
-	resp, err := c.httpClient.PostForm(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointSiteAdd), values)
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointSiteAdd), values, false)
 	if err != nil {
 		return nil, fmt.Errorf("Error adding site for domain %s: %s", domain, err)
 	}
@@ -245,22 +256,23 @@ func (c *Client) AddSite(domain, refID, sendSiteSetupEmails, siteIP, forceSSL st
 
 	// Look at the response status code from Incapsula
 	if siteAddResponse.Res != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when adding site for domain %s: %s", domain, string(responseBody))
+		return nil, newAPIError(resp, responseBody, "Error from Incapsula service when adding site for domain %s", domain)
 	}
 
 	return &siteAddResponse, nil
 }
 
 // SiteStatus gets the Incapsula managed site's status
-func (c *Client) SiteStatus(domain string, siteID int) (*SiteStatusResponse, error) {
+func (c *Client) SiteStatus(ctx context.Context, domain string, siteID int, accountID int) (*SiteStatusResponse, error) {
 	log.Printf("[INFO] Getting Incapsula site status for domain: %s (site id: %d)\n", domain, siteID)
 
-	// Post form to Incapsula
-	resp, err := c.httpClient.PostForm(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointSiteStatus), url.Values{
-		"api_id":  {c.config.APIID},
-		"api_key": {c.config.APIKey},
+	values := url.Values{
 		"site_id": {strconv.Itoa(siteID)},
-	})
+	}
+	c.setAccountID(values, accountID)
+
+	// Post form to Incapsula
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointSiteStatus), values, true)
 	if err != nil {
 		return nil, fmt.Errorf("Error getting site status for domain %s (site id: %d): %s", domain, siteID, err)
 	}
@@ -279,34 +291,178 @@ func (c *Client) SiteStatus(domain string, siteID int) (*SiteStatusResponse, err
 		return nil, fmt.Errorf("Error parsing site status JSON response for domain %s (site id: %d): %s", domain, siteID, err)
 	}
 
-	var resString string
+	// Look at the response status code from Incapsula
+	if siteStatusResponse.Res != 0 {
+		return &siteStatusResponse, newAPIError(resp, responseBody, "Error from Incapsula service when getting site status for domain %s (site id: %d)", domain, siteID)
+	}
+
+	return &siteStatusResponse, nil
+}
+
+// parseSiteValidationRecords decodes the validation data of the generated certificate of the site.
+// The shape of the data depends on the validation method: a list of DNS records for dns, a map of
+// domain to meta tags for html and a list (or map of domain to list) of email addresses for email.
+func parseSiteValidationRecords(validationMethod string, validationData interface{}) ([]SiteValidationRecord, error) {
+	records := make([]SiteValidationRecord, 0)
+	if validationData == nil {
+		return records, nil
+	}
+
+	// Re-encode the data so that it can be decoded into the expected shape
+	data, err := json.Marshal(validationData)
+	if err != nil {
+		return nil, fmt.Errorf("Error encoding %s validation data: %s", validationMethod, err)
+	}
+
+	switch validationMethod {
+	case "dns":
+		var dnsValidationData []SiteStatusDNSValidationData
+		if err := json.Unmarshal(data, &dnsValidationData); err != nil {
+			// Some responses contain a single record instead of a list
+			var dnsRecord SiteStatusDNSValidationData
+			if err := json.Unmarshal(data, &dnsRecord); err != nil {
+				return nil, fmt.Errorf("Error parsing dns validation data: %s", string(data))
+			}
+			dnsValidationData = []SiteStatusDNSValidationData{dnsRecord}
+		}
+		for _, dnsRecord := range dnsValidationData {
+			records = append(records, SiteValidationRecord{
+				Name:   dnsRecord.DNSRecordName,
+				Type:   dnsRecord.SetTypeTo,
+				Values: dnsRecord.SetDataTo,
+				Method: validationMethod,
+			})
+		}
+
+	case "html", "email":
+		recordType := "meta"
+		if validationMethod == "email" {
+			recordType = "email"
+		}
+
+		var values []string
+		if err := json.Unmarshal(data, &values); err == nil {
+			records = append(records, SiteValidationRecord{Type: recordType, Values: values, Method: validationMethod})
+			break
+		}
+
+		var valuesByName map[string][]string
+		if err := json.Unmarshal(data, &valuesByName); err != nil {
+			return nil, fmt.Errorf("Error parsing %s validation data: %s", validationMethod, string(data))
+		}
+
+		// Sort the names so that the records don't change order between reads
+		names := make([]string, 0, len(valuesByName))
+		for name := range valuesByName {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			records = append(records, SiteValidationRecord{Name: name, Type: recordType, Values: valuesByName[name], Method: validationMethod})
+		}
+
+	default:
+		return nil, fmt.Errorf("Unknown validation method: %s", validationMethod)
+	}
+
+	return records, nil
+}
+
+// SiteListResponse contains a page of the managed sites
+type SiteListResponse struct {
+	Sites      []SiteStatusResponse `json:"sites"`
+	Res        ResCode              `json:"res"`
+	ResMessage string               `json:"res_message"`
+}
+
+// ListSites gets a page (starting at 0) of the Incapsula managed sites of the account
+func (c *Client) ListSites(ctx context.Context, accountID, pageSize, pageNum int) (*SiteListResponse, error) {
+	log.Printf("[INFO] Getting Incapsula sites (account id: %d, page: %d)\n", c.accountID(accountID), pageNum)
+
+	values := url.Values{
+		"page_size": {strconv.Itoa(pageSize)},
+		"page_num":  {strconv.Itoa(pageNum)},
+	}
+	c.setAccountID(values, accountID)
+
+	// Post form to Incapsula
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointSiteList), values, true)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting sites (account id: %d, page: %d): %s", c.accountID(accountID), pageNum, err)
+	}
+
+	// Read the body
+	defer resp.Body.Close()
+	responseBody, err := ioutil.ReadAll(resp.Body)
+
+	// Dump JSON
+	log.Printf("[DEBUG] Incapsula site list JSON response: %s\n", string(responseBody))
 
-	if resNumber, ok := siteStatusResponse.Res.(float64); ok {
-		resString = fmt.Sprintf("%d", int(resNumber))
-	} else {
-		resString = siteStatusResponse.Res.(string)
+	// Parse the JSON
+	var siteListResponse SiteListResponse
+	err = json.Unmarshal([]byte(responseBody), &siteListResponse)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing site list JSON response (account id: %d, page: %d): %s\nresponse: %s", c.accountID(accountID), pageNum, err, redactSecrets(string(responseBody)))
 	}
 
 	// Look at the response status code from Incapsula
-	if resString != "0" {
-		return &siteStatusResponse, fmt.Errorf("Error from Incapsula service when getting site status for domain %s (site id: %d): %s", domain, siteID, string(responseBody))
+	if siteListResponse.Res != 0 {
+		return nil, newAPIError(resp, responseBody, "Error from Incapsula service when getting sites (account id: %d, page: %d)", c.accountID(accountID), pageNum)
 	}
 
-	return &siteStatusResponse, nil
+	return &siteListResponse, nil
+}
+
+// ListAllSites pages through all the Incapsula managed sites of the account
+func (c *Client) ListAllSites(ctx context.Context, accountID int) ([]SiteStatusResponse, error) {
+	sites := make([]SiteStatusResponse, 0)
+	listed := make(map[int]bool)
+
+	for pageNum := 0; pageNum < siteListMaxPages; pageNum++ {
+		siteListResponse, err := c.ListSites(ctx, accountID, siteListPageSize, pageNum)
+		if err != nil {
+			return nil, err
+		}
+
+		newSites := 0
+		for _, site := range siteListResponse.Sites {
+			if listed[site.SiteID] {
+				continue
+			}
+			listed[site.SiteID] = true
+			sites = append(sites, site)
+			newSites++
+		}
+
+		// A short page is the last one
+		if len(siteListResponse.Sites) < siteListPageSize {
+			return sites, nil
+		}
+
+		// A page with only sites already listed means the API didn't move to the next page
+		if newSites == 0 {
+			log.Printf("[WARN] Incapsula site list page %d (account id: %d) only repeats sites already listed, stopping\n", pageNum, c.accountID(accountID))
+			return sites, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Error getting sites (account id: %d): more than %d pages of %d sites", c.accountID(accountID), siteListMaxPages, siteListPageSize)
 }
 
 // UpdateSite will update the specific param/value on the site resource
-func (c *Client) UpdateSite(siteID, param, value string) (*SiteUpdateResponse, error) {
+func (c *Client) UpdateSite(ctx context.Context, siteID, param, value string, accountID int) (*SiteUpdateResponse, error) {
 	log.Printf("[INFO] Updating Incapsula site for siteID: %s\n", siteID)
 
-	// Post form to Incapsula
-	resp, err := c.httpClient.PostForm(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointSiteUpdate), url.Values{
-		"api_id":  {c.config.APIID},
-		"api_key": {c.config.APIKey},
+	values := url.Values{
 		"site_id": {siteID},
 		"param":   {param},
 		"value":   {value},
-	})
+	}
+	c.setAccountID(values, accountID)
+
+	// Post form to Incapsula
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointSiteUpdate), values, true)
 	if err != nil {
 		return nil, fmt.Errorf("Error updating param (%s) with value (%s) on site_id: %s: %s", param, value, siteID, err)
 	}
@@ -327,29 +483,30 @@ func (c *Client) UpdateSite(siteID, param, value string) (*SiteUpdateResponse, e
 
 	// Look at the response status code from Incapsula
 	if siteUpdateResponse.Res != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when updating site for siteID %s: %s", siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, "Error from Incapsula service when updating site for siteID %s", siteID)
 	}
 
 	return &siteUpdateResponse, nil
 }
 
 // DeleteSite deletes a site currently managed by Incapsula
-func (c *Client) DeleteSite(domain string, siteID int) error {
+func (c *Client) DeleteSite(ctx context.Context, domain string, siteID int, accountID int) error {
 	// Specifically shaded this struct, no need to share across funcs or export
 	// We only care about the response code and possibly the message
 	type SiteDeleteResponse struct {
-		Res        int    `json:"res"`
-		ResMessage string `json:"res_message"`
+		Res        ResCode `json:"res"`
+		ResMessage string  `json:"res_message"`
 	}
 
 	log.Printf("[INFO] Deleting Incapsula site for domain: %s (site id: %d)\n", domain, siteID)
 
-	// Post form to Incapsula
-	resp, err := c.httpClient.PostForm(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointSiteDelete), url.Values{
-		"api_id":  {c.config.APIID},
-		"api_key": {c.config.APIKey},
+	values := url.Values{
 		"site_id": {strconv.Itoa(siteID)},
-	})
+	}
+	c.setAccountID(values, accountID)
+
+	// Post form to Incapsula
+	resp, err := c.postForm(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointSiteDelete), values, true)
 	if err != nil {
 		return fmt.Errorf("Error deleting site for domain %s (site id: %d): %s", domain, siteID, err)
 	}
@@ -370,7 +527,7 @@ func (c *Client) DeleteSite(domain string, siteID int) error {
 
 	// Look at the response status code from Incapsula
 	if siteDeleteResponse.Res != 0 {
-		return fmt.Errorf("Error from Incapsula service when deleting site for domain %s (site id: %d): %s", domain, siteID, string(responseBody))
+		return newAPIError(resp, responseBody, "Error from Incapsula service when deleting site for domain %s (site id: %d)", domain, siteID)
 	}
 
 	return nil
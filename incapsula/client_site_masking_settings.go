@@ -1,7 +1,7 @@
 package incapsula
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -16,11 +16,11 @@ type MaskingSettings struct {
 }
 
 // GetMaskingSettings gets the site masking settings
-func (c *Client) GetMaskingSettings(siteID string) (*MaskingSettings, error) {
+func (c *Client) GetMaskingSettings(ctx context.Context, siteID string, accountID int) (*MaskingSettings, error) {
 	log.Printf("[INFO] Getting Incapsula Masking Settings for Site ID %s\n", siteID)
 
 	// Post form to Incapsula
-	resp, err := c.httpClient.Get(fmt.Sprintf("%s/sites/%s/settings/masking?api_id=%s&api_key=%s", c.config.BaseURLRev2, siteID, c.config.APIID, c.config.APIKey))
+	resp, err := c.doJSONRequest(ctx, http.MethodGet, c.withAccountID(fmt.Sprintf("%s/sites/%s/settings/masking", c.config.BaseURLRev2, siteID), accountID), nil)
 	if err != nil {
 		return nil, fmt.Errorf("Error from Incapsula service when reading masking settings for Site ID %s: %s", siteID, err)
 	}
@@ -34,37 +34,36 @@ func (c *Client) GetMaskingSettings(siteID string) (*MaskingSettings, error) {
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when reading masking settings for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, "Error status code %d from Incapsula service when reading masking settings for Site ID %s", resp.StatusCode, siteID)
 	}
 
 	// Parse the JSON
 	var maskingSettings MaskingSettings
 	err = json.Unmarshal([]byte(responseBody), &maskingSettings)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Incap masking settings JSON response for Site ID %s: %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Incap masking settings JSON response for Site ID %s: %s\nresponse: %s", siteID, err, redactSecrets(string(responseBody)))
 	}
 
 	return &maskingSettings, nil
 }
 
 // UpdateMaskingSettings updates the site masking settings
-func (c *Client) UpdateMaskingSettings(siteID string, maskingSettings *MaskingSettings) error {
+func (c *Client) UpdateMaskingSettings(ctx context.Context, siteID string, maskingSettings *MaskingSettings, accountID int) error {
 	log.Printf("[INFO] Updating Incapsula masking settings for Site ID %s\n", siteID)
 
+	registerSecret(maskingSettings.HashSalt)
+
 	maskingSettingsJSON, err := json.Marshal(maskingSettings)
 	if err != nil {
 		return fmt.Errorf("Failed to JSON marshal MaskingSettings: %s", err)
 	}
 
 	// Put request to Incapsula
-	req, err := http.NewRequest(
+	resp, err := c.doJSONRequest(
+		ctx,
 		http.MethodPost,
-		fmt.Sprintf("%s/sites/%s/settings/masking?api_id=%s&api_key=%s", c.config.BaseURLRev2, siteID, c.config.APIID, c.config.APIKey),
-		bytes.NewReader(maskingSettingsJSON))
-	if err != nil {
-		return fmt.Errorf("Error preparing HTTP PUT for updating Incap masking settings for Site ID %s: %s", siteID, err)
-	}
-	resp, err := c.httpClient.Do(req)
+		c.withAccountID(fmt.Sprintf("%s/sites/%s/settings/masking", c.config.BaseURLRev2, siteID), accountID),
+		maskingSettingsJSON)
 	if err != nil {
 		return fmt.Errorf("Error from Incapsula service when updating masking settings for Site ID %s: %s", siteID, err)
 	}
@@ -78,7 +77,7 @@ func (c *Client) UpdateMaskingSettings(siteID string, maskingSettings *MaskingSe
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from Incapsula service when updating masking settings for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody))
+		return newAPIError(resp, responseBody, "Error status code %d from Incapsula service when updating masking settings for Site ID %s", resp.StatusCode, siteID)
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -17,7 +18,7 @@ func TestClientGetMaskingSettingsBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := "123"
-	maskingSettings, err := client.GetMaskingSettings(siteID)
+	maskingSettings, err := client.GetMaskingSettings(context.Background(), siteID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -34,7 +35,7 @@ func TestClientGetMaskingSettingsBadJSON(t *testing.T) {
 	apiKey := "bar"
 	siteID := "42"
 
-	endpoint := fmt.Sprintf("/sites/%s/settings/masking?api_id=%s&api_key=%s", siteID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/settings/masking", siteID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		if req.URL.String() != endpoint {
@@ -47,7 +48,7 @@ func TestClientGetMaskingSettingsBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	maskingSettings, err := client.GetMaskingSettings(siteID)
+	maskingSettings, err := client.GetMaskingSettings(context.Background(), siteID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -64,7 +65,7 @@ func TestClientGetMaskingSettingsInvalidSite(t *testing.T) {
 	apiKey := "bar"
 	siteID := "42"
 
-	endpoint := fmt.Sprintf("/sites/%s/settings/masking?api_id=%s&api_key=%s", siteID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/settings/masking", siteID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.WriteHeader(404)
@@ -78,7 +79,7 @@ func TestClientGetMaskingSettingsInvalidSite(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	maskingSettings, err := client.GetMaskingSettings(siteID)
+	maskingSettings, err := client.GetMaskingSettings(context.Background(), siteID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -95,7 +96,7 @@ func TestClientGetMaskingSettingsValidSite(t *testing.T) {
 	apiKey := "bar"
 	siteID := "42"
 
-	endpoint := fmt.Sprintf("/sites/%s/settings/masking?api_id=%s&api_key=%s", siteID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/settings/masking", siteID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		if req.URL.String() != endpoint {
@@ -108,7 +109,7 @@ func TestClientGetMaskingSettingsValidSite(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	maskingSettings, err := client.GetMaskingSettings(siteID)
+	maskingSettings, err := client.GetMaskingSettings(context.Background(), siteID, 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -132,7 +133,7 @@ func TestClientUpdateMaskingSettingsSettingsBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := "123"
 	maskingSettings := MaskingSettings{HashingEnabled: true, HashSalt: "salt"}
-	err := client.UpdateMaskingSettings(siteID, &maskingSettings)
+	err := client.UpdateMaskingSettings(context.Background(), siteID, &maskingSettings, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -147,7 +148,7 @@ func TestClientUpdateMaskingSettingsInvalidSite(t *testing.T) {
 	siteID := "42"
 	maskingSettings := MaskingSettings{HashingEnabled: true, HashSalt: "salt"}
 
-	endpoint := fmt.Sprintf("/sites/%s/settings/masking?api_id=%s&api_key=%s", siteID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/settings/masking", siteID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.WriteHeader(404)
@@ -161,7 +162,7 @@ func TestClientUpdateMaskingSettingsInvalidSite(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.UpdateMaskingSettings(siteID, &maskingSettings)
+	err := client.UpdateMaskingSettings(context.Background(), siteID, &maskingSettings, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -176,7 +177,7 @@ func TestClientUpdateMaskingSettingsValidSite(t *testing.T) {
 	siteID := "42"
 	maskingSettings := MaskingSettings{HashingEnabled: true, HashSalt: "salt"}
 
-	endpoint := fmt.Sprintf("/sites/%s/settings/masking?api_id=%s&api_key=%s", siteID, apiID, apiKey)
+	endpoint := fmt.Sprintf("/sites/%s/settings/masking", siteID)
 
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		if req.URL.String() != endpoint {
@@ -189,7 +190,27 @@ func TestClientUpdateMaskingSettingsValidSite(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.UpdateMaskingSettings(siteID, &maskingSettings)
+	err := client.UpdateMaskingSettings(context.Background(), siteID, &maskingSettings, 0)
+	if err != nil {
+		t.Errorf("Should not have received an error")
+	}
+}
+
+func TestClientUpdateMaskingSettingsAccountID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/sites/42/settings/masking" {
+			t.Errorf("Should have have hit /sites/42/settings/masking endpoint. Got: %s", req.URL.Path)
+		}
+		if caid := req.URL.Query().Get("caid"); caid != "5678" {
+			t.Errorf("Should have sent the account_id override as caid, got: %s", caid)
+		}
+		rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURLRev2: server.URL, AccountID: 1234}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	err := client.UpdateMaskingSettings(context.Background(), "42", &MaskingSettings{}, 5678)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -1,9 +1,12 @@
 package incapsula
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -17,7 +20,7 @@ func TestClientAddSiteBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	domain := "foo.com"
-	addSiteResponse, err := client.AddSite(domain, "", "", "", "", 0)
+	addSiteResponse, err := client.AddSite(context.Background(), domain, "", "", "", "", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -41,7 +44,7 @@ func TestClientAddSiteBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	domain := "foo.com"
-	addSiteResponse, err := client.AddSite(domain, "", "", "", "", 0)
+	addSiteResponse, err := client.AddSite(context.Background(), domain, "", "", "", "", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -65,7 +68,7 @@ func TestClientAddSiteInvalidSite(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	domain := "foo.com"
-	addSiteResponse, err := client.AddSite(domain, "", "", "", "", 0)
+	addSiteResponse, err := client.AddSite(context.Background(), domain, "", "", "", "", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -89,7 +92,7 @@ func TestClientAddSiteValidSite(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	domain := "foo.com"
-	addSiteResponse, err := client.AddSite(domain, "", "", "", "", 0)
+	addSiteResponse, err := client.AddSite(context.Background(), domain, "", "", "", "", 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -113,7 +116,7 @@ func TestClientSiteStatusBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	domain := "foo.com"
 	siteID := 123
-	siteStatusResponse, err := client.SiteStatus(domain, siteID)
+	siteStatusResponse, err := client.SiteStatus(context.Background(), domain, siteID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -138,7 +141,7 @@ func TestClientSiteStatusBadJSON(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	domain := "foo.com"
 	siteID := 123
-	siteStatusResponse, err := client.SiteStatus(domain, siteID)
+	siteStatusResponse, err := client.SiteStatus(context.Background(), domain, siteID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -163,7 +166,7 @@ func TestClientSiteStatusInvalidSite(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	domain := "foo.com"
 	siteID := 123
-	siteStatusResponse, err := client.SiteStatus(domain, siteID)
+	siteStatusResponse, err := client.SiteStatus(context.Background(), domain, siteID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -188,7 +191,7 @@ func TestClientSiteStatusValidSite(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	domain := "foo.com"
 	siteID := 123
-	siteStatusResponse, err := client.SiteStatus(domain, siteID)
+	siteStatusResponse, err := client.SiteStatus(context.Background(), domain, siteID, 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -203,6 +206,30 @@ func TestClientSiteStatusValidSite(t *testing.T) {
 	}
 }
 
+func TestClientSiteStatusAccountID(t *testing.T) {
+	expected := "1234"
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if accountID := req.FormValue("account_id"); accountID != expected {
+			t.Errorf("Should have sent account_id %q, got: %q", expected, accountID)
+		}
+		rw.Write([]byte(`{"res":0}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL, AccountID: 1234}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	// The provider account_id is sent unless another account is given
+	if _, err := client.SiteStatus(context.Background(), "foo.com", 123, 0); err != nil {
+		t.Errorf("Should not have received an error")
+	}
+
+	expected = "5678"
+	if _, err := client.SiteStatus(context.Background(), "foo.com", 123, 5678); err != nil {
+		t.Errorf("Should not have received an error")
+	}
+}
+
 ////////////////////////////////////////////////////////////////
 // UpdateSite Tests
 ////////////////////////////////////////////////////////////////
@@ -213,7 +240,7 @@ func TestClientUpdateSiteBadConnection(t *testing.T) {
 	siteID := "42"
 	param := "active"
 	value := "bypass"
-	updateSiteResponse, err := client.UpdateSite(siteID, param, value)
+	updateSiteResponse, err := client.UpdateSite(context.Background(), siteID, param, value, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -237,7 +264,7 @@ func TestClientUpdateSiteBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "42"
-	updateSiteResponse, err := client.UpdateSite(siteID, "", "")
+	updateSiteResponse, err := client.UpdateSite(context.Background(), siteID, "", "", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -261,7 +288,7 @@ func TestClientUpdateSiteInvalidSite(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "42"
-	updateSiteResponse, err := client.UpdateSite(siteID, "", "")
+	updateSiteResponse, err := client.UpdateSite(context.Background(), siteID, "", "", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -285,7 +312,7 @@ func TestClientUpdateSiteValidSite(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "42"
-	addSiteResponse, err := client.UpdateSite(siteID, "", "")
+	addSiteResponse, err := client.UpdateSite(context.Background(), siteID, "", "", 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -309,7 +336,7 @@ func TestClientDeleteSiteBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	domain := "foo.com"
 	siteID := 123
-	err := client.DeleteSite(domain, siteID)
+	err := client.DeleteSite(context.Background(), domain, siteID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -331,7 +358,7 @@ func TestClientDeleteSiteBadJSON(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	domain := "foo.com"
 	siteID := 123
-	err := client.DeleteSite(domain, siteID)
+	err := client.DeleteSite(context.Background(), domain, siteID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -353,7 +380,7 @@ func TestClientDeleteSiteInvalidSite(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	domain := "foo.com"
 	siteID := 123
-	err := client.DeleteSite(domain, siteID)
+	err := client.DeleteSite(context.Background(), domain, siteID, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -375,8 +402,276 @@ func TestClientDeleteSiteValidSite(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	domain := "foo.com"
 	siteID := 123
-	err := client.DeleteSite(domain, siteID)
+	err := client.DeleteSite(context.Background(), domain, siteID, 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
 }
+
+////////////////////////////////////////////////////////////////
+// ListSites Tests
+////////////////////////////////////////////////////////////////
+
+func TestClientListSitesBadConnection(t *testing.T) {
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
+	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
+	siteListResponse, err := client.ListSites(context.Background(), 0, siteListPageSize, 0)
+	if err == nil {
+		t.Errorf("Should have received an error")
+	}
+	if !strings.HasPrefix(err.Error(), "Error getting sites (account id: 0, page: 0)") {
+		t.Errorf("Should have received an client error, got: %s", err)
+	}
+	if siteListResponse != nil {
+		t.Errorf("Should have received a nil siteListResponse instance")
+	}
+}
+
+func TestClientListSitesInvalidAccount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.String() != fmt.Sprintf("/%s", endpointSiteList) {
+			t.Errorf("Should have have hit /%s endpoint. Got: %s", endpointSiteList, req.URL.String())
+		}
+		rw.Write([]byte(`{"res":9403,"res_message":"Unknown/unauthorized account_id"}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	siteListResponse, err := client.ListSites(context.Background(), 1234, siteListPageSize, 0)
+	if err == nil {
+		t.Errorf("Should have received an error")
+	}
+	if !strings.HasPrefix(err.Error(), "Error from Incapsula service when getting sites (account id: 1234, page: 0)") {
+		t.Errorf("Should have received a bad account error, got: %s", err)
+	}
+	if siteListResponse != nil {
+		t.Errorf("Should have received a nil siteListResponse instance")
+	}
+}
+
+func TestClientListSitesValidAccount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.String() != fmt.Sprintf("/%s", endpointSiteList) {
+			t.Errorf("Should have have hit /%s endpoint. Got: %s", endpointSiteList, req.URL.String())
+		}
+		if req.FormValue("page_size") != "100" || req.FormValue("page_num") != "2" || req.FormValue("account_id") != "1234" {
+			t.Errorf("Should have sent the page and account, got: %s", req.Form.Encode())
+		}
+		rw.Write([]byte(`{"sites":[{"site_id":42,"domain":"www.example.com","dns":[{"dns_record_name":"www.example.com","set_type_to":"CNAME","set_data_to":["abc.x.incapdns.net"]}]}],"res":0,"res_message":"OK"}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	siteListResponse, err := client.ListSites(context.Background(), 1234, siteListPageSize, 2)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if len(siteListResponse.Sites) != 1 || siteListResponse.Sites[0].SiteID != 42 {
+		t.Errorf("Should have parsed the sites, got: %+v", siteListResponse.Sites)
+	}
+	if siteListResponse.Sites[0].DNS[0].SetDataTo[0] != "abc.x.incapdns.net" {
+		t.Errorf("Should have parsed the DNS records, got: %+v", siteListResponse.Sites[0].DNS)
+	}
+}
+
+////////////////////////////////////////////////////////////////
+// ListAllSites Tests
+////////////////////////////////////////////////////////////////
+
+// testSiteListServer serves pages of sites, pageSites returns the site IDs of a page
+func testSiteListServer(t *testing.T, pageSites func(pageNum int) []int) (*httptest.Server, *int) {
+	pages := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.String() != fmt.Sprintf("/%s", endpointSiteList) {
+			t.Errorf("Should have have hit /%s endpoint. Got: %s", endpointSiteList, req.URL.String())
+		}
+		pages++
+
+		pageNum, _ := strconv.Atoi(req.FormValue("page_num"))
+		sites := make([]string, 0, siteListPageSize)
+		for _, siteID := range pageSites(pageNum) {
+			sites = append(sites, fmt.Sprintf(`{"site_id":%d,"domain":"www.example%d.com"}`, siteID, siteID))
+		}
+		rw.Write([]byte(`{"sites":[` + strings.Join(sites, ",") + `],"res":0}`))
+	}))
+	return server, &pages
+}
+
+func testSiteListPage(first, count int) []int {
+	siteIDs := make([]int, 0, count)
+	for i := 0; i < count; i++ {
+		siteIDs = append(siteIDs, first+i)
+	}
+	return siteIDs
+}
+
+func TestClientListAllSites(t *testing.T) {
+	// Two full pages and a short one
+	server, pages := testSiteListServer(t, func(pageNum int) []int {
+		if pageNum < 2 {
+			return testSiteListPage(pageNum*siteListPageSize+1, siteListPageSize)
+		}
+		return testSiteListPage(pageNum*siteListPageSize+1, 5)
+	})
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	sites, err := client.ListAllSites(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if len(sites) != 2*siteListPageSize+5 || *pages != 3 {
+		t.Errorf("Should have listed %d sites in 3 pages, got %d sites in %d pages", 2*siteListPageSize+5, len(sites), *pages)
+	}
+}
+
+func TestClientListAllSitesEmptyPage(t *testing.T) {
+	// A full page, then nothing
+	server, pages := testSiteListServer(t, func(pageNum int) []int {
+		if pageNum == 0 {
+			return testSiteListPage(1, siteListPageSize)
+		}
+		return nil
+	})
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	sites, err := client.ListAllSites(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if len(sites) != siteListPageSize || *pages != 2 {
+		t.Errorf("Should have listed %d sites in 2 pages, got %d sites in %d pages", siteListPageSize, len(sites), *pages)
+	}
+}
+
+func TestClientListAllSitesRepeatedPage(t *testing.T) {
+	// The API ignores the page number and always returns the first page
+	server, pages := testSiteListServer(t, func(pageNum int) []int {
+		return testSiteListPage(1, siteListPageSize)
+	})
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	sites, err := client.ListAllSites(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if len(sites) != siteListPageSize || *pages != 2 {
+		t.Errorf("Should have stopped at the repeated page with %d sites, got %d sites in %d pages", siteListPageSize, len(sites), *pages)
+	}
+}
+
+func TestClientListAllSitesMaxPages(t *testing.T) {
+	defer func(maxPages int) { siteListMaxPages = maxPages }(siteListMaxPages)
+	siteListMaxPages = 3
+
+	// Full pages of new sites, forever
+	server, pages := testSiteListServer(t, func(pageNum int) []int {
+		return testSiteListPage(pageNum*siteListPageSize+1, siteListPageSize)
+	})
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	_, err := client.ListAllSites(context.Background(), 0)
+	if err == nil || !strings.HasPrefix(err.Error(), "Error getting sites (account id: 0): more than 3 pages") {
+		t.Errorf("Should have received a maximum pages error, got: %v", err)
+	}
+	if *pages != 3 {
+		t.Errorf("Should have stopped after 3 pages, got: %d", *pages)
+	}
+}
+
+////////////////////////////////////////////////////////////////
+// parseSiteValidationRecords Tests
+////////////////////////////////////////////////////////////////
+
+func testParseValidationData(t *testing.T, data string) interface{} {
+	var validationData interface{}
+	if err := json.Unmarshal([]byte(data), &validationData); err != nil {
+		t.Fatal(err)
+	}
+	return validationData
+}
+
+func TestParseSiteValidationRecordsDNS(t *testing.T) {
+	validationData := testParseValidationData(t, `[{"dns_record_name":"example.com","set_type_to":"TXT","set_data_to":["globalsign-domain-verification=abc"]},{"dns_record_name":"www.example.com","set_type_to":"TXT","set_data_to":["globalsign-domain-verification=def"]}]`)
+	records, err := parseSiteValidationRecords("dns", validationData)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Should have parsed 2 records, got: %+v", records)
+	}
+	if records[1].Name != "www.example.com" || records[1].Type != "TXT" || records[1].Values[0] != "globalsign-domain-verification=def" || records[1].Method != "dns" {
+		t.Errorf("Should have parsed the second record, got: %+v", records[1])
+	}
+}
+
+func TestParseSiteValidationRecordsDNSSingleRecord(t *testing.T) {
+	validationData := testParseValidationData(t, `{"dns_record_name":"example.com","set_type_to":"TXT","set_data_to":["globalsign-domain-verification=abc"]}`)
+	records, err := parseSiteValidationRecords("dns", validationData)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if len(records) != 1 || records[0].Name != "example.com" {
+		t.Errorf("Should have parsed the record, got: %+v", records)
+	}
+}
+
+func TestParseSiteValidationRecordsHTML(t *testing.T) {
+	validationData := testParseValidationData(t, `{"www.example.com":["<meta name=\"globalsign-domain-verification\" content=\"def\"/>"],"example.com":["<meta name=\"globalsign-domain-verification\" content=\"abc\"/>"]}`)
+	records, err := parseSiteValidationRecords("html", validationData)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Should have parsed 2 records, got: %+v", records)
+	}
+	if records[0].Name != "example.com" || records[1].Name != "www.example.com" {
+		t.Errorf("Should have sorted the records by name, got: %+v", records)
+	}
+	if records[0].Type != "meta" || records[0].Method != "html" || !strings.Contains(records[0].Values[0], `content="abc"`) {
+		t.Errorf("Should have parsed the meta tag, got: %+v", records[0])
+	}
+}
+
+func TestParseSiteValidationRecordsEmail(t *testing.T) {
+	validationData := testParseValidationData(t, `["admin@example.com","webmaster@example.com"]`)
+	records, err := parseSiteValidationRecords("email", validationData)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if len(records) != 1 || records[0].Type != "email" || len(records[0].Values) != 2 {
+		t.Errorf("Should have parsed the email addresses, got: %+v", records)
+	}
+}
+
+func TestParseSiteValidationRecordsUnexpectedShape(t *testing.T) {
+	for method, data := range map[string]string{
+		"dns":   `[{"set_data_to":"not-a-list"}]`,
+		"html":  `{"example.com":[{"tag":"not-a-string"}]}`,
+		"email": `42`,
+		"other": `[]`,
+	} {
+		if _, err := parseSiteValidationRecords(method, testParseValidationData(t, data)); err == nil {
+			t.Errorf("Should have received an error for %s validation data %s", method, data)
+		}
+	}
+}
+
+func TestParseSiteValidationRecordsNoData(t *testing.T) {
+	records, err := parseSiteValidationRecords("dns", nil)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if len(records) != 0 {
+		t.Errorf("Should not have parsed any records, got: %+v", records)
+	}
+}
@@ -0,0 +1,65 @@
+package incapsula
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Supported values of the tls_min_version provider argument
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// newHTTPClient builds the HTTP client used by the request pipeline
+// Proxy and TLS settings come from the provider configuration, everything else from the Go defaults
+func newHTTPClient(config *Config) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	// Without an explicit proxy, the HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables apply
+	if strings.TrimSpace(config.ProxyURL) != "" {
+		proxyURL, err := url.Parse(strings.TrimSpace(config.ProxyURL))
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("Invalid proxy URL (proxy_url): %s", config.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+
+	if config.TLSMinVersion != "" {
+		minVersion, ok := tlsVersions[config.TLSMinVersion]
+		if !ok {
+			return nil, fmt.Errorf("Invalid TLS minimum version (tls_min_version): %s", config.TLSMinVersion)
+		}
+		tlsConfig.MinVersion = minVersion
+	}
+
+	// The extra CA certificates are trusted in addition to the system ones
+	if strings.TrimSpace(config.CACertificatePEM) != "" {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil || rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM([]byte(config.CACertificatePEM)) {
+			return nil, fmt.Errorf("No valid PEM encoded certificate found in the CA certificate (ca_certificate_pem)")
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   config.RequestTimeout,
+	}, nil
+}
@@ -0,0 +1,156 @@
+package incapsula
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////
+// newHTTPClient Tests
+////////////////////////////////////////////////////////////////
+
+func TestNewHTTPClientDefaults(t *testing.T) {
+	httpClient, err := newHTTPClient(&Config{RequestTimeout: 30 * time.Second})
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if httpClient.Timeout != 30*time.Second {
+		t.Errorf("Should have set a 30s timeout, got: %s", httpClient.Timeout)
+	}
+
+	transport := httpClient.Transport.(*http.Transport)
+	if transport.TLSClientConfig.MinVersion != tls.VersionTLS12 {
+		t.Errorf("Should have defaulted to TLS 1.2, got: %x", transport.TLSClientConfig.MinVersion)
+	}
+	if transport.TLSClientConfig.InsecureSkipVerify {
+		t.Errorf("Should verify server certificates by default")
+	}
+	if transport.TLSClientConfig.RootCAs != nil {
+		t.Errorf("Should have used the system CA certificates")
+	}
+}
+
+func TestNewHTTPClientTLSMinVersion(t *testing.T) {
+	httpClient, err := newHTTPClient(&Config{TLSMinVersion: "1.3"})
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if httpClient.Transport.(*http.Transport).TLSClientConfig.MinVersion != tls.VersionTLS13 {
+		t.Errorf("Should have set TLS 1.3 as the minimum version")
+	}
+
+	_, err = newHTTPClient(&Config{TLSMinVersion: "2.0"})
+	if err == nil || !strings.HasPrefix(err.Error(), "Invalid TLS minimum version") {
+		t.Errorf("Should have received an invalid TLS version error, got: %v", err)
+	}
+}
+
+func TestNewHTTPClientInvalidProxyURL(t *testing.T) {
+	_, err := newHTTPClient(&Config{ProxyURL: "proxy.example.com"})
+	if err == nil || !strings.HasPrefix(err.Error(), "Invalid proxy URL") {
+		t.Errorf("Should have received an invalid proxy URL error, got: %v", err)
+	}
+}
+
+func TestNewHTTPClientInvalidCACertificate(t *testing.T) {
+	_, err := newHTTPClient(&Config{CACertificatePEM: "not a certificate"})
+	if err == nil || !strings.HasPrefix(err.Error(), "No valid PEM encoded certificate found") {
+		t.Errorf("Should have received an invalid CA certificate error, got: %v", err)
+	}
+}
+
+func TestNewHTTPClientCustomCACertificate(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	// The test server certificate is not trusted by the system
+	config := &Config{APIID: "foo", APIKey: "bar"}
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if _, err := client.doJSONRequest(context.Background(), http.MethodGet, server.URL, nil); err == nil {
+		t.Errorf("Should have received a certificate error")
+	}
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	config = &Config{APIID: "foo", APIKey: "bar", CACertificatePEM: string(caPEM)}
+	client, err = NewClient(config)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	resp, err := client.doJSONRequest(context.Background(), http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("Should have trusted the custom CA certificate, got: %s", err)
+	}
+	resp.Body.Close()
+}
+
+func TestNewHTTPClientInsecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{APIID: "foo", APIKey: "bar", InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	resp, err := client.doJSONRequest(context.Background(), http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("Should have skipped the certificate verification, got: %s", err)
+	}
+	resp.Body.Close()
+}
+
+func TestNewHTTPClientProxyURL(t *testing.T) {
+	proxied := false
+	proxy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		// Requests sent through a proxy carry the absolute URL of the target
+		if req.URL.Host == "my.incapsula.example" && req.URL.Path == "/api/prov/v1/account" {
+			proxied = true
+		}
+		rw.Write([]byte(`{"res":0}`))
+	}))
+	defer proxy.Close()
+
+	client, err := NewClient(&Config{APIID: "foo", APIKey: "bar", ProxyURL: proxy.URL})
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	resp, err := client.doJSONRequest(context.Background(), http.MethodGet, "http://my.incapsula.example/api/prov/v1/account", nil)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	resp.Body.Close()
+
+	if !proxied {
+		t.Errorf("Should have sent the request through the proxy")
+	}
+}
+
+func TestNewHTTPClientRequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		select {
+		case <-req.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{APIID: "foo", APIKey: "bar", RequestTimeout: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if _, err := client.doJSONRequest(context.Background(), http.MethodGet, server.URL, nil); err == nil {
+		t.Errorf("Should have received a timeout error")
+	}
+}
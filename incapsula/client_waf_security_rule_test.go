@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -22,7 +23,7 @@ func TestClientConfigureWAFSecurityRuleBadConnection(t *testing.T) {
 	siteID := 1234
 	ruleID := "api.threats.backdoor"
 	securityRuleAction := "badRuleAction"
-	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(siteID, ruleID, securityRuleAction, "", "", "", "")
+	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(context.Background(), siteID, ruleID, securityRuleAction, "", "", "", "", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -50,7 +51,7 @@ func TestClientConfigureWAFSecurityRuleBadJSON(t *testing.T) {
 	siteID := 1234
 	ruleID := "api.threats.backdoor"
 	securityRuleAction := "badRuleAction"
-	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(siteID, ruleID, securityRuleAction, "", "", "", "")
+	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(context.Background(), siteID, ruleID, securityRuleAction, "", "", "", "", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -78,7 +79,7 @@ func TestClientConfigureWAFSecurityRuleInvalidRuleID(t *testing.T) {
 	siteID := 1234
 	ruleID := "bad_rule_id"
 	securityRuleAction := "bad_rule_action"
-	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(siteID, ruleID, securityRuleAction, "", "", "", "")
+	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(context.Background(), siteID, ruleID, securityRuleAction, "", "", "", "", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -106,7 +107,7 @@ func TestClientConfigureWAFSecurityRuleInvalidRuleAction(t *testing.T) {
 	siteID := 1234
 	ruleID := "api.threats.backdoor"
 	securityRuleAction := "bad_rule_action"
-	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(siteID, ruleID, securityRuleAction, "", "", "", "")
+	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(context.Background(), siteID, ruleID, securityRuleAction, "", "", "", "", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -135,7 +136,7 @@ func TestClientConfigureWAFSecurityRuleInvalidRule_activationMode(t *testing.T)
 	ruleID := backdoorRuleID
 	activationMode := "api.threats.ddos.activation_mode.on"
 	ddosTrafficThreshold := "123"
-	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(siteID, ruleID, "", activationMode, ddosTrafficThreshold, "", "")
+	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(context.Background(), siteID, ruleID, "", activationMode, ddosTrafficThreshold, "", "", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -164,7 +165,7 @@ func TestClientConfigureWAFSecurityRuleInvalidRule_ddosThreshold(t *testing.T) {
 	ruleID := ddosRuleID
 	activationMode := "api.threats.ddos.activation_mode.on"
 	ddosTrafficThreshold := "123"
-	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(siteID, ruleID, "", activationMode, ddosTrafficThreshold, "", "")
+	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(context.Background(), siteID, ruleID, "", activationMode, ddosTrafficThreshold, "", "", 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -193,7 +194,7 @@ func TestClientConfigureWAFSecurityRuleInvalidRule_blockBadBots(t *testing.T) {
 	ruleID := botAccessControlRuleID
 	challengeSuspectedBots := "true"
 	blockBadBots := "123"
-	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(siteID, ruleID, "", "", "", blockBadBots, challengeSuspectedBots)
+	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(context.Background(), siteID, ruleID, "", "", "", blockBadBots, challengeSuspectedBots, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -222,7 +223,7 @@ func TestClientConfigureWAFSecurityRuleInvalidRule_challengeSuspectedBots(t *tes
 	ruleID := botAccessControlRuleID
 	challengeSuspectedBots := "123"
 	blockBadBots := "true"
-	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(siteID, ruleID, "", "", "", blockBadBots, challengeSuspectedBots)
+	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(context.Background(), siteID, ruleID, "", "", "", blockBadBots, challengeSuspectedBots, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -254,7 +255,7 @@ func TestClientConfigureWAFSecurityRuleValidRule(t *testing.T) {
 	siteID := 1234
 	ruleID := backdoorRuleID
 	securityRuleAction := "api.threats.action.quarantine_url"
-	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(siteID, ruleID, securityRuleAction, "", "", "", "")
+	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(context.Background(), siteID, ruleID, securityRuleAction, "", "", "", "", 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -279,7 +280,7 @@ func TestClientConfigureWAFSecurityRuleResultCodeStringValidRule(t *testing.T) {
 	siteID := 1234
 	ruleID := backdoorRuleID
 	securityRuleAction := "api.threats.action.quarantine_url"
-	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(siteID, ruleID, securityRuleAction, "", "", "", "")
+	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(context.Background(), siteID, ruleID, securityRuleAction, "", "", "", "", 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -287,3 +288,21 @@ func TestClientConfigureWAFSecurityRuleResultCodeStringValidRule(t *testing.T) {
 		t.Errorf("Should not have received a nil configureWAFSecurityRuleResponse instance")
 	}
 }
+
+func TestClientConfigureWAFSecurityRuleAccountID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if accountID := req.FormValue("account_id"); accountID != "1234" {
+			t.Errorf("Should have sent the provider account_id, got: %s", accountID)
+		}
+		rw.Write([]byte(`{"site_id":123,"res":"0"}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL, AccountID: 1234}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	siteID, ruleID := 42, backdoorRuleID
+	_, err := client.ConfigureWAFSecurityRule(context.Background(), siteID, ruleID, "api.threats.action.block_ip", "", "", "", "", 0)
+	if err != nil {
+		t.Errorf("Should not have received an error")
+	}
+}
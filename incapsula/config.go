@@ -1,9 +1,11 @@
 package incapsula
 
 import (
+	"context"
 	"errors"
 	"log"
 	"strings"
+	"time"
 )
 
 // Config represents the configuration required for the Incapsula Client
@@ -14,6 +16,16 @@ type Config struct {
 	// API Key
 	APIKey string
 
+	// Shared credentials file, ~/.incapsula/credentials when empty
+	SharedCredentialsFile string
+
+	// Profile of the shared credentials file, default when empty
+	Profile string
+
+	// Account to operate on when a resource doesn't specify one
+	// 0 means the account identified by the API credentials
+	AccountID int
+
 	// Base URL (no trailing slash)
 	// This endpoint is unlikely to change in the near future
 	BaseURL string
@@ -28,6 +40,44 @@ type Config struct {
 	// API V2
 	// Same as revision 2 but with a different subdomain
 	BaseURLAPI string
+
+	// Maximum number of attempts for a single API request (including the first one)
+	// Values lower than 1 disable retries
+	RetryMaxAttempts int
+
+	// Maximum total time spent waiting between the attempts of a single API request
+	RetryMaxWait time.Duration
+
+	// Maximum average number of API requests per second (0 means unlimited)
+	RequestsPerSecond float64
+
+	// Maximum number of API requests in flight at the same time (0 means unlimited)
+	MaxConcurrentRequests int
+
+	// Timeout of a single HTTP request attempt (0 means no timeout)
+	RequestTimeout time.Duration
+
+	// Proxy for all API requests, the proxy environment variables apply when empty
+	ProxyURL string
+
+	// PEM encoded CA certificates trusted in addition to the system ones
+	CACertificatePEM string
+
+	// Minimum TLS version (1.0, 1.1, 1.2 or 1.3), defaults to 1.2
+	TLSMinVersion string
+
+	// Skip the verification of the server certificate (testing only)
+	InsecureSkipVerify bool
+
+	// User-Agent header sent with all API requests
+	UserAgent string
+
+	// Skip the account check of the API credentials
+	SkipCredentialsValidation bool
+
+	// Defer the credentials checks until the first API call
+	// Operations that don't call the API (validate, schema) then work without credentials
+	Offline bool
 }
 
 var missingAPIIDMessage = "API Identifier (api_id) must be provided"
@@ -37,17 +87,20 @@ var missingBaseURLRev2Message = "Base URL Revision 2 must be provided"
 var missingBaseURLAPIMessage = "Base URL API must be provided"
 
 // Client configures and returns a fully initialized Incapsula Client
-func (c *Config) Client() (interface{}, error) {
-	log.Println("[INFO] Checking API credentials for client instantiation")
-
-	// Check API Identifier
-	if strings.TrimSpace(c.APIID) == "" {
-		return nil, errors.New(missingAPIIDMessage)
-	}
-
-	// Check API Key
-	if strings.TrimSpace(c.APIKey) == "" {
-		return nil, errors.New(missingAPIKeyMessage)
+func (c *Config) Client(ctx context.Context) (interface{}, error) {
+	// Credentials are checked on the first API call in offline mode
+	if !c.Offline {
+		log.Println("[INFO] Checking API credentials for client instantiation")
+
+		// Check API Identifier
+		if strings.TrimSpace(c.APIID) == "" {
+			return nil, errors.New(missingAPIIDMessage)
+		}
+
+		// Check API Key
+		if strings.TrimSpace(c.APIKey) == "" {
+			return nil, errors.New(missingAPIKeyMessage)
+		}
 	}
 
 	// Check Base URL
@@ -65,14 +118,30 @@ func (c *Config) Client() (interface{}, error) {
 		return nil, errors.New(missingBaseURLAPIMessage)
 	}
 
-	// Create client
-	client := NewClient(c)
+	// Mask secrets in all log output from here on
+	installLogRedaction()
 
-	// Verify client credentials
-	_, err := client.Verify()
+	// Create client
+	client, err := NewClient(c)
 	if err != nil {
 		return nil, err
 	}
 
+	switch {
+	case c.Offline:
+		log.Println("[INFO] Offline mode, deferring the API credentials check to the first API call")
+		client.deferCredentialsCheck()
+
+	case c.SkipCredentialsValidation:
+		log.Println("[INFO] Skipping the API credentials validation")
+
+	default:
+		// Verify client credentials
+		_, err = client.Verify(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return client, nil
 }
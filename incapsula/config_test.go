@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -9,7 +10,7 @@ import (
 
 func TestMissingCredentials(t *testing.T) {
 	config := Config{}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error, got a client: %q", client)
 	}
@@ -20,7 +21,7 @@ func TestMissingCredentials(t *testing.T) {
 
 func TestMissingAPIID(t *testing.T) {
 	config := Config{APIID: "", APIKey: "foo"}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error, got a client: %q", client)
 	}
@@ -31,7 +32,7 @@ func TestMissingAPIID(t *testing.T) {
 
 func TestMissingAPIKey(t *testing.T) {
 	config := Config{APIID: "foo", APIKey: ""}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error, got a client: %q", client)
 	}
@@ -42,7 +43,7 @@ func TestMissingAPIKey(t *testing.T) {
 
 func TestMissingBaseURL(t *testing.T) {
 	config := Config{APIID: "foo", APIKey: "bar", BaseURL: ""}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error, got a client: %q", client)
 	}
@@ -53,7 +54,7 @@ func TestMissingBaseURL(t *testing.T) {
 
 func TestMissingBaseURLRev2(t *testing.T) {
 	config := Config{APIID: "foo", APIKey: "bar", BaseURL: "foobar.com", BaseURLRev2: ""}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error, got a client: %q", client)
 	}
@@ -64,7 +65,7 @@ func TestMissingBaseURLRev2(t *testing.T) {
 
 func TestMissingBaseURLAPI(t *testing.T) {
 	config := Config{APIID: "foo", APIKey: "bar", BaseURL: "foobar.com", BaseURLRev2: "foobar.com", BaseURLAPI: ""}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error, got a client: %q", client)
 	}
@@ -83,7 +84,7 @@ func TestInvalidCredentials(t *testing.T) {
 	defer server.Close()
 
 	config := Config{APIID: "bad", APIKey: "bad", BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error, got a client: %q", client)
 	}
@@ -102,7 +103,7 @@ func TestValidCredentials(t *testing.T) {
 	defer server.Close()
 
 	config := Config{APIID: "good", APIKey: "good", BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err != nil {
 		t.Errorf("Should not have received an error, got: %s", err)
 	}
@@ -110,3 +111,84 @@ func TestValidCredentials(t *testing.T) {
 		t.Error("Client should not be nil")
 	}
 }
+
+func TestSkipCredentialsValidation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		t.Errorf("Should not have hit any endpoint. Got: %s", req.URL.String())
+	}))
+	defer server.Close()
+
+	config := Config{APIID: "good", APIKey: "good", BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL, SkipCredentialsValidation: true}
+	client, err := config.Client(context.Background())
+	if err != nil {
+		t.Errorf("Should not have received an error, got: %s", err)
+	}
+	if client == nil {
+		t.Error("Client should not be nil")
+	}
+}
+
+func TestOfflineMissingCredentials(t *testing.T) {
+	config := Config{BaseURL: "foobar.com", BaseURLRev2: "foobar.com", BaseURLAPI: "foobar.com", Offline: true}
+	client, err := config.Client(context.Background())
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+
+	_, err = client.(*Client).SiteStatus(context.Background(), "www.example.com", 42, 0)
+	if err == nil || !strings.HasSuffix(err.Error(), missingAPIIDMessage) {
+		t.Errorf("Should have received missing API ID message on the first API call, got: %v", err)
+	}
+}
+
+func TestOfflineDeferredCredentialsCheck(t *testing.T) {
+	accountRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.String() == "/account" {
+			accountRequests++
+		} else if accountRequests == 0 {
+			t.Errorf("Should have checked the account before hitting %s", req.URL.String())
+		}
+		rw.Write([]byte(`{"res":0,"res_message":"OK","site_id":42}`))
+	}))
+	defer server.Close()
+
+	config := Config{APIID: "good", APIKey: "good", BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL, Offline: true}
+	client, err := config.Client(context.Background())
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if accountRequests != 0 {
+		t.Errorf("Should not have checked the account when configuring the client")
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.(*Client).SiteStatus(context.Background(), "www.example.com", 42, 0); err != nil {
+			t.Errorf("Should not have received an error, got: %s", err)
+		}
+	}
+	if accountRequests != 1 {
+		t.Errorf("Should have checked the account exactly once, got: %d", accountRequests)
+	}
+}
+
+func TestOfflineInvalidCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.String() != "/account" {
+			t.Errorf("Should have have hit /account endpoint. Got: %s", req.URL.String())
+		}
+		rw.Write([]byte(`{"res":9411,"res_message":"Authentication parameters missing or incorrect"}`))
+	}))
+	defer server.Close()
+
+	config := Config{APIID: "bad", APIKey: "bad", BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL, Offline: true}
+	client, err := config.Client(context.Background())
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+
+	_, err = client.(*Client).SiteStatus(context.Background(), "www.example.com", 42, 0)
+	if err == nil || !strings.Contains(err.Error(), "Error from Incapsula service when checking account") {
+		t.Errorf("Should have received Incapsula service error on the first API call, got: %v", err)
+	}
+}
@@ -0,0 +1,236 @@
+package incapsula
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Default location and profile of the shared credentials file
+const (
+	defaultSharedCredentialsFile = "~/.incapsula/credentials"
+	defaultProfile               = "default"
+)
+
+// credentialsProfile is a named section of the shared credentials file
+type credentialsProfile struct {
+	APIID       string
+	APIKey      string
+	BaseURL     string
+	BaseURLRev2 string
+	BaseURLAPI  string
+}
+
+// parseCredentialsFile parses an INI file into its profiles
+// Keys before the first [profile] header, blank lines and ; or # comments are ignored
+func parseCredentialsFile(r io.Reader) (map[string]*credentialsProfile, error) {
+	profiles := make(map[string]*credentialsProfile)
+
+	var profile *credentialsProfile
+	var profileName string
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("Invalid profile header on line %d: %s", lineNumber, line)
+			}
+			profileName = strings.TrimSpace(line[1 : len(line)-1])
+			if profileName == "" {
+				return nil, fmt.Errorf("Empty profile name on line %d", lineNumber)
+			}
+			if profiles[profileName] == nil {
+				profiles[profileName] = &credentialsProfile{}
+			}
+			profile = profiles[profileName]
+			continue
+		}
+
+		separator := strings.IndexAny(line, "=:")
+		if separator < 0 {
+			return nil, fmt.Errorf("Invalid key/value pair on line %d", lineNumber)
+		}
+		if profile == nil {
+			continue
+		}
+
+		key := strings.TrimSpace(line[:separator])
+		value := strings.Trim(strings.TrimSpace(line[separator+1:]), `"'`)
+		profile.set(profileName, key, value)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
+
+// parseYAMLCredentialsFile parses a YAML file mapping the profile names to their settings into its profiles
+// Only this subset of YAML is supported: unindented profile names, indented key: value pairs and # comments
+func parseYAMLCredentialsFile(r io.Reader) (map[string]*credentialsProfile, error) {
+	profiles := make(map[string]*credentialsProfile)
+
+	var profile *credentialsProfile
+	var profileName string
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		rawLine := strings.TrimRight(scanner.Text(), " \t\r")
+		line := strings.TrimSpace(rawLine)
+		if line == "" || strings.HasPrefix(line, "#") || line == "---" {
+			continue
+		}
+
+		separator := strings.Index(line, ":")
+		if separator < 0 {
+			return nil, fmt.Errorf("Invalid key/value pair on line %d", lineNumber)
+		}
+		key := strings.TrimSpace(line[:separator])
+		value := strings.TrimSpace(line[separator+1:])
+
+		// Unquoted values end at a comment
+		if strings.HasPrefix(value, `"`) || strings.HasPrefix(value, "'") {
+			value = strings.Trim(value, `"'`)
+		} else if comment := strings.Index(value, " #"); comment >= 0 {
+			value = strings.TrimSpace(value[:comment])
+		}
+
+		if rawLine == line {
+			if value != "" {
+				return nil, fmt.Errorf("Invalid profile on line %d, expected a profile name followed by indented settings", lineNumber)
+			}
+			profileName = strings.Trim(key, `"'`)
+			if profileName == "" {
+				return nil, fmt.Errorf("Empty profile name on line %d", lineNumber)
+			}
+			if profiles[profileName] == nil {
+				profiles[profileName] = &credentialsProfile{}
+			}
+			profile = profiles[profileName]
+			continue
+		}
+
+		if profile == nil {
+			return nil, fmt.Errorf("Setting outside of a profile on line %d", lineNumber)
+		}
+		profile.set(profileName, strings.Trim(key, `"'`), value)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
+
+// set sets a setting of the profile from its key in the shared credentials file
+func (p *credentialsProfile) set(profileName, key, value string) {
+	switch key {
+	case "api_id":
+		p.APIID = value
+	case "api_key":
+		p.APIKey = value
+	case "base_url":
+		p.BaseURL = value
+	case "base_url_rev_2":
+		p.BaseURLRev2 = value
+	case "base_url_api":
+		p.BaseURLAPI = value
+	default:
+		log.Printf("[WARN] Ignoring unknown key %s in credentials profile %s\n", key, profileName)
+	}
+}
+
+// expandHomeDir replaces a leading ~ with the home directory of the current user
+func expandHomeDir(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(homeDir, path[1:]), nil
+}
+
+// applyProfile fills the credentials and base URLs that are not configured explicitly from the shared credentials file
+// A missing file or profile is only an error when it has been selected explicitly
+func (c *Config) applyProfile() error {
+	path := c.SharedCredentialsFile
+	if path == "" {
+		path = defaultSharedCredentialsFile
+	}
+
+	profileName := c.Profile
+	if profileName == "" {
+		profileName = defaultProfile
+	}
+
+	explicit := c.SharedCredentialsFile != "" || c.Profile != ""
+
+	path, err := expandHomeDir(path)
+	if err != nil {
+		return fmt.Errorf("Error resolving the shared credentials file %s: %s", path, err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) && !explicit {
+			return nil
+		}
+		return fmt.Errorf("Error reading the shared credentials file %s: %s", path, err)
+	}
+	defer file.Close()
+
+	// The file is INI, unless its extension is .yaml or .yml
+	parse := parseCredentialsFile
+	if extension := strings.ToLower(filepath.Ext(path)); extension == ".yaml" || extension == ".yml" {
+		parse = parseYAMLCredentialsFile
+	}
+
+	profiles, err := parse(file)
+	if err != nil {
+		return fmt.Errorf("Error parsing the shared credentials file %s: %s", path, err)
+	}
+
+	profile, ok := profiles[profileName]
+	if !ok {
+		if !explicit {
+			return nil
+		}
+		return fmt.Errorf("Profile %s not found in the shared credentials file %s", profileName, path)
+	}
+
+	log.Printf("[INFO] Using profile %s from the shared credentials file %s\n", profileName, path)
+
+	// Explicit arguments and environment variables take precedence over the profile
+	if c.APIID == "" {
+		c.APIID = profile.APIID
+	}
+	if c.APIKey == "" {
+		c.APIKey = profile.APIKey
+	}
+	if c.BaseURL == "" {
+		c.BaseURL = profile.BaseURL
+	}
+	if c.BaseURLRev2 == "" {
+		c.BaseURLRev2 = profile.BaseURLRev2
+	}
+	if c.BaseURLAPI == "" {
+		c.BaseURLAPI = profile.BaseURLAPI
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+package incapsula
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testCredentialsFile = `
+# Production account
+[default]
+api_id = 1234
+api_key = prod-api-key
+
+[staging]
+api_id     = 5678
+api_key    = "staging-api-key"
+base_url   = https://staging.example.com/api/prov/v1
+base_url_rev_2: https://staging.example.com/api/prov/v2
+base_url_api = https://api.staging.example.com
+`
+
+const testYAMLCredentialsFile = `
+---
+# Production account
+default:
+  api_id: 1234
+  api_key: prod-api-key # rotated yearly
+
+staging:
+  api_id:     5678
+  api_key:    "staging-api-key"
+  base_url:   https://staging.example.com/api/prov/v1
+  base_url_rev_2: 'https://staging.example.com/api/prov/v2'
+  base_url_api: https://api.staging.example.com
+`
+
+func writeTestCredentialsFile(t *testing.T, content string) string {
+	return writeTestCredentialsFileNamed(t, "credentials", content)
+}
+
+func writeTestCredentialsFileNamed(t *testing.T, name, content string) string {
+	dir, err := ioutil.TempDir("", "incapsula-credentials")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+////////////////////////////////////////////////////////////////
+// parseCredentialsFile Tests
+////////////////////////////////////////////////////////////////
+
+func TestParseCredentialsFile(t *testing.T) {
+	profiles, err := parseCredentialsFile(strings.NewReader(testCredentialsFile))
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if len(profiles) != 2 {
+		t.Fatalf("Should have parsed 2 profiles, got: %d", len(profiles))
+	}
+	if profiles["default"].APIID != "1234" || profiles["default"].APIKey != "prod-api-key" {
+		t.Errorf("Should have parsed the default profile, got: %+v", profiles["default"])
+	}
+	staging := profiles["staging"]
+	if staging.APIKey != "staging-api-key" {
+		t.Errorf("Should have removed the quotes, got: %s", staging.APIKey)
+	}
+	if staging.BaseURLRev2 != "https://staging.example.com/api/prov/v2" || staging.BaseURLAPI != "https://api.staging.example.com" {
+		t.Errorf("Should have parsed the staging base URLs, got: %+v", staging)
+	}
+}
+
+func TestParseCredentialsFileInvalid(t *testing.T) {
+	for _, content := range []string{"[default\napi_id = 1234", "[]\napi_id = 1234", "[default]\napi_id 1234"} {
+		if _, err := parseCredentialsFile(strings.NewReader(content)); err == nil {
+			t.Errorf("Should have received an error for %q", content)
+		}
+	}
+}
+
+func TestParseYAMLCredentialsFile(t *testing.T) {
+	profiles, err := parseYAMLCredentialsFile(strings.NewReader(testYAMLCredentialsFile))
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if len(profiles) != 2 {
+		t.Fatalf("Should have parsed 2 profiles, got: %d", len(profiles))
+	}
+	if profiles["default"].APIID != "1234" || profiles["default"].APIKey != "prod-api-key" {
+		t.Errorf("Should have parsed the default profile without the comment, got: %+v", profiles["default"])
+	}
+	staging := profiles["staging"]
+	if staging.APIKey != "staging-api-key" {
+		t.Errorf("Should have removed the quotes, got: %s", staging.APIKey)
+	}
+	if staging.BaseURLRev2 != "https://staging.example.com/api/prov/v2" || staging.BaseURLAPI != "https://api.staging.example.com" {
+		t.Errorf("Should have parsed the staging base URLs, got: %+v", staging)
+	}
+}
+
+func TestParseYAMLCredentialsFileInvalid(t *testing.T) {
+	for _, content := range []string{"  api_id: 1234", "default: 1234", "default:\n  api_id 1234", ":\n  api_id: 1234"} {
+		if _, err := parseYAMLCredentialsFile(strings.NewReader(content)); err == nil {
+			t.Errorf("Should have received an error for %q", content)
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////
+// applyProfile Tests
+////////////////////////////////////////////////////////////////
+
+func TestApplyProfileNamedProfile(t *testing.T) {
+	path := writeTestCredentialsFile(t, testCredentialsFile)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	config := Config{SharedCredentialsFile: path, Profile: "staging"}
+	if err := config.applyProfile(); err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if config.APIID != "5678" || config.APIKey != "staging-api-key" {
+		t.Errorf("Should have used the staging credentials, got: %s/%s", config.APIID, config.APIKey)
+	}
+	if config.BaseURL != "https://staging.example.com/api/prov/v1" {
+		t.Errorf("Should have used the staging base URL, got: %s", config.BaseURL)
+	}
+}
+
+func TestApplyProfileYAMLFile(t *testing.T) {
+	for _, name := range []string{"credentials.yaml", "credentials.YML"} {
+		path := writeTestCredentialsFileNamed(t, name, testYAMLCredentialsFile)
+		defer os.RemoveAll(filepath.Dir(path))
+
+		config := Config{SharedCredentialsFile: path, Profile: "staging"}
+		if err := config.applyProfile(); err != nil {
+			t.Fatalf("%s: Should not have received an error, got: %s", name, err)
+		}
+		if config.APIID != "5678" || config.APIKey != "staging-api-key" {
+			t.Errorf("%s: Should have used the staging credentials, got: %s/%s", name, config.APIID, config.APIKey)
+		}
+	}
+}
+
+func TestApplyProfileDefaultProfile(t *testing.T) {
+	path := writeTestCredentialsFile(t, testCredentialsFile)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	config := Config{SharedCredentialsFile: path}
+	if err := config.applyProfile(); err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if config.APIID != "1234" || config.APIKey != "prod-api-key" {
+		t.Errorf("Should have used the default credentials, got: %s/%s", config.APIID, config.APIKey)
+	}
+	if config.BaseURL != "" {
+		t.Errorf("Should not have set a base URL, got: %s", config.BaseURL)
+	}
+}
+
+func TestApplyProfileExplicitSettingsTakePrecedence(t *testing.T) {
+	path := writeTestCredentialsFile(t, testCredentialsFile)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	config := Config{APIID: "explicit", BaseURLAPI: "https://api.example.com", SharedCredentialsFile: path, Profile: "staging"}
+	if err := config.applyProfile(); err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if config.APIID != "explicit" || config.BaseURLAPI != "https://api.example.com" {
+		t.Errorf("Should have kept the explicit settings, got: %s/%s", config.APIID, config.BaseURLAPI)
+	}
+	if config.APIKey != "staging-api-key" {
+		t.Errorf("Should have used the staging API key, got: %s", config.APIKey)
+	}
+}
+
+func TestApplyProfileMissingProfile(t *testing.T) {
+	path := writeTestCredentialsFile(t, testCredentialsFile)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	config := Config{SharedCredentialsFile: path, Profile: "qa"}
+	err := config.applyProfile()
+	if err == nil || !strings.HasPrefix(err.Error(), "Profile qa not found") {
+		t.Errorf("Should have received a profile not found error, got: %v", err)
+	}
+}
+
+func TestApplyProfileMissingFile(t *testing.T) {
+	config := Config{SharedCredentialsFile: filepath.Join(os.TempDir(), "incapsula-missing-credentials")}
+	err := config.applyProfile()
+	if err == nil || !strings.HasPrefix(err.Error(), "Error reading the shared credentials file") {
+		t.Errorf("Should have received a read error, got: %v", err)
+	}
+
+	// Without an explicit file or profile, the default file is optional
+	home := os.Getenv("HOME")
+	defer os.Setenv("HOME", home)
+	os.Setenv("HOME", os.TempDir())
+
+	config = Config{}
+	if err := config.applyProfile(); err != nil {
+		t.Errorf("Should not have received an error, got: %s", err)
+	}
+}
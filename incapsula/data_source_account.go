@@ -0,0 +1,147 @@
+package incapsula
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAccount() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAccountRead,
+
+		Schema: map[string]*schema.Schema{
+			// Optional Arguments
+			"account_id": {
+				Description: "Numeric identifier of the account (or sub-account) to read. If not specified, the provider account_id or the account identified by the authentication parameters is used.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+			},
+
+			// Computed Attributes
+			"account_name": {
+				Description: "The account name.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"email": {
+				Description: "The email address of the account owner.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"user_name": {
+				Description: "The name of the account owner.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"ref_id": {
+				Description: "Customer specific identifier of the account.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"plan_id": {
+				Description: "The plan identifier.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"plan_name": {
+				Description: "The plan name.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"trial_end_date": {
+				Description: "The end date of the trial, if the account is on a trial.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"support_level": {
+				Description: "The support level of the account.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"support_all_tls_versions": {
+				Description: "Whether new sites support all TLS versions.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"wildcard_san_for_new_sites": {
+				Description: "Whether a wildcard SAN is added to the certificates of new sites (Default, True or False).",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"naked_domain_san_for_new_www_sites": {
+				Description: "Whether the naked domain SAN is added to the certificates of new www sites.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"logins": {
+				Description: "The logins of the account.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"login_id": {
+							Description: "Numeric identifier of the login.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"email": {
+							Description: "The email address of the login.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"email_verified": {
+							Description: "Whether the email address has been verified.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	accountResponse, err := client.GetAccount(ctx, d.Get("account_id").(int))
+	if err != nil {
+		log.Printf("[ERROR] Could not read Incapsula account: %s\n", err)
+		return diag.FromErr(err)
+	}
+
+	account := accountResponse.Account
+
+	d.SetId(strconv.Itoa(account.AccountID))
+	d.Set("account_id", account.AccountID)
+	d.Set("account_name", account.AccountName)
+	d.Set("email", account.Email)
+	d.Set("user_name", account.UserName)
+	d.Set("ref_id", account.RefID)
+	d.Set("plan_id", account.PlanID)
+	d.Set("plan_name", account.PlanName)
+	d.Set("trial_end_date", account.TrialEndDate)
+	d.Set("support_level", account.SupportLevel)
+	d.Set("support_all_tls_versions", account.SupportAllTLSVersions)
+	d.Set("wildcard_san_for_new_sites", account.WildcardSANForNewSites)
+	d.Set("naked_domain_san_for_new_www_sites", account.NakedDomainSANForNewWWWSites)
+
+	logins := make([]interface{}, 0, len(account.Logins))
+	for _, login := range account.Logins {
+		logins = append(logins, map[string]interface{}{
+			"login_id":       int(login.LoginID),
+			"email":          login.Email,
+			"email_verified": login.EmailVerified,
+		})
+	}
+	if err := d.Set("logins", logins); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package incapsula
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const accountDataSourceName = "data.incapsula_account.testacc-terraform-account"
+
+func TestAccIncapsulaAccountDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIncapsulaAccountDataSourceConfigBasic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(accountDataSourceName, "account_id"),
+					resource.TestCheckResourceAttrSet(accountDataSourceName, "plan_name"),
+					resource.TestCheckResourceAttrSet(accountDataSourceName, "support_level"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIncapsulaAccountDataSourceConfigBasic() string {
+	return `
+data "incapsula_account" "testacc-terraform-account" {}
+`
+}
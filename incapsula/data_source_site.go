@@ -0,0 +1,195 @@
+package incapsula
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSite() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSiteRead,
+
+		Schema: map[string]*schema.Schema{
+			// Optional Arguments
+			"site_id": {
+				Description:  "Numeric identifier of the site to look up.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"site_id", "domain"},
+			},
+			"domain": {
+				Description:  "The domain of the site to look up.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"site_id", "domain"},
+			},
+			"account_id": {
+				Description: "Numeric identifier of the account to look up the domain in. If not specified, the provider account_id or the account identified by the authentication parameters is used.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+			},
+
+			// Computed Attributes
+			"status": {
+				Description: "The status of the site, e.g. fully_configured or pending-dns-changes.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"active": {
+				Description: "Whether the site is active or bypassed (active or bypass).",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"acceleration_level": {
+				Description: "The acceleration level of the site (none, standard or aggressive).",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"display_name": {
+				Description: "The display name of the site.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"ref_id": {
+				Description: "Customer specific identifier of the site.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"site_creation_date": {
+				Description: "Numeric representation of the site creation date.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"ips": {
+				Description: "The IPs of the origin servers.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"dns_records":          siteDNSRecordsSchema("The DNS records to set to route the site traffic through Incapsula."),
+			"original_dns_records": siteDNSRecordsSchema("The DNS records of the site before it was onboarded."),
+			"ssl_validation_status": {
+				Description: "The validation status of the generated certificate, e.g. ok or pending_user_action.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"ssl_validation_method": {
+				Description: "The validation method of the generated certificate (dns, email or html).",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// siteDNSRecordsSchema is the schema of a list of site DNS records (SiteStatusDNSRecord)
+func siteDNSRecordsSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Description: description,
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The record name.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"type": {
+					Description: "The record type, e.g. A or CNAME.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"values": {
+					Description: "The record values.",
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+// flattenSiteDNSRecords converts site DNS records to the siteDNSRecordsSchema format
+func flattenSiteDNSRecords(records []SiteStatusDNSRecord) []interface{} {
+	flattened := make([]interface{}, 0, len(records))
+	for _, record := range records {
+		flattened = append(flattened, map[string]interface{}{
+			"name":   record.DNSRecordName,
+			"type":   record.SetTypeTo,
+			"values": record.SetDataTo,
+		})
+	}
+	return flattened
+}
+
+// findSiteIDByDomain looks up the domain in the sites of the account
+func findSiteIDByDomain(ctx context.Context, client *Client, domain string, accountID int) (int, error) {
+	sites, err := client.ListAllSites(ctx, accountID)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, site := range sites {
+		if strings.EqualFold(site.Domain, domain) {
+			return site.SiteID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("No Incapsula site found for domain %s", domain)
+}
+
+func dataSourceSiteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	siteID := d.Get("site_id").(int)
+	domain := d.Get("domain").(string)
+
+	if siteID == 0 {
+		var err error
+		siteID, err = findSiteIDByDomain(ctx, client, domain, d.Get("account_id").(int))
+		if err != nil {
+			log.Printf("[ERROR] Could not look up Incapsula site for domain: %s, %s\n", domain, err)
+			return diag.FromErr(err)
+		}
+	}
+
+	siteStatusResponse, err := client.SiteStatus(ctx, domain, siteID, d.Get("account_id").(int))
+	if err != nil {
+		log.Printf("[ERROR] Could not read Incapsula site %d: %s\n", siteID, err)
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(siteStatusResponse.SiteID))
+	d.Set("site_id", siteStatusResponse.SiteID)
+	d.Set("domain", siteStatusResponse.Domain)
+	d.Set("account_id", siteStatusResponse.AccountID)
+	d.Set("status", siteStatusResponse.Status)
+	d.Set("active", siteStatusResponse.Active)
+	d.Set("acceleration_level", siteStatusResponse.AccelerationLevel)
+	d.Set("display_name", siteStatusResponse.DisplayName)
+	d.Set("ref_id", siteStatusResponse.RefID)
+	d.Set("site_creation_date", siteStatusResponse.SiteCreationDate)
+	d.Set("ips", siteStatusResponse.Ips)
+	d.Set("ssl_validation_status", siteStatusResponse.Ssl.GeneratedCertificate.ValidationStatus)
+	d.Set("ssl_validation_method", siteStatusResponse.Ssl.GeneratedCertificate.ValidationMethod)
+
+	if err := d.Set("dns_records", flattenSiteDNSRecords(siteStatusResponse.DNS)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("original_dns_records", flattenSiteDNSRecords(siteStatusResponse.OriginalDNS)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package incapsula
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const siteDataSourceName = "data.incapsula_site.testacc-terraform-site"
+
+func TestAccIncapsulaSiteDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIncapsulaSiteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIncapsulaSiteDataSourceConfigBasic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(siteDataSourceName, "site_id", siteResourceName, "id"),
+					resource.TestCheckResourceAttr(siteDataSourceName, "domain", testAccDomain),
+					resource.TestCheckResourceAttrSet(siteDataSourceName, "dns_records.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIncapsulaSiteDataSourceConfigBasic() string {
+	return testAccCheckIncapsulaSiteConfigBasic(testAccDomain) + `
+data "incapsula_site" "testacc-terraform-site" {
+  domain = "${incapsula_site.testacc-terraform-site.domain}"
+}
+`
+}
+
+func TestFindSiteIDByDomainPaginates(t *testing.T) {
+	pages := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.String() != fmt.Sprintf("/%s", endpointSiteList) {
+			t.Errorf("Should have have hit /%s endpoint. Got: %s", endpointSiteList, req.URL.String())
+		}
+		pages++
+
+		// A full first page, then the matching site on the second one
+		sites := make([]string, 0, siteListPageSize)
+		if req.FormValue("page_num") == "0" {
+			for i := 0; i < siteListPageSize; i++ {
+				sites = append(sites, fmt.Sprintf(`{"site_id":%d,"domain":"www.example%d.com"}`, i+1, i))
+			}
+		} else {
+			sites = append(sites, `{"site_id":4242,"domain":"WWW.Example.com"}`)
+		}
+		rw.Write([]byte(`{"sites":[` + strings.Join(sites, ",") + `],"res":0}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	siteID, err := findSiteIDByDomain(context.Background(), client, "www.example.com", 0)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if siteID != 4242 || pages != 2 {
+		t.Errorf("Should have found site 4242 on the second page, got: %d after %d pages", siteID, pages)
+	}
+
+	_, err = findSiteIDByDomain(context.Background(), client, "www.missing.com", 0)
+	if err == nil || !strings.HasPrefix(err.Error(), "No Incapsula site found for domain www.missing.com") {
+		t.Errorf("Should have received a not found error, got: %v", err)
+	}
+}
+
+func TestDataSourceSiteReadAccountID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if accountID := req.FormValue("account_id"); accountID != "5678" {
+			t.Errorf("Should have sent the account_id of the data source to %s, got: %s", req.URL.Path, accountID)
+		}
+		switch req.URL.Path {
+		case "/" + endpointSiteList:
+			rw.Write([]byte(`{"sites":[{"site_id":42,"domain":"www.example.com"}],"res":0}`))
+		case "/" + endpointSiteStatus:
+			rw.Write([]byte(`{"site_id":42,"domain":"www.example.com","account_id":5678,"res":0}`))
+		default:
+			t.Errorf("Unexpected request to %s", req.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL, AccountID: 1234}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	d := schema.TestResourceDataRaw(t, dataSourceSite().Schema, map[string]interface{}{
+		"domain":     "www.example.com",
+		"account_id": 5678,
+	})
+
+	if diags := dataSourceSiteRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("Should not have received an error, got: %v", diags)
+	}
+	if d.Id() != "42" || d.Get("account_id").(int) != 5678 {
+		t.Errorf("Should have read site 42 of account 5678, got: %s (account %d)", d.Id(), d.Get("account_id").(int))
+	}
+}
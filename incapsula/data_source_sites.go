@@ -0,0 +1,152 @@
+package incapsula
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSites() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSitesRead,
+
+		Schema: map[string]*schema.Schema{
+			// Optional Arguments
+			"account_id": {
+				Description: "Numeric identifier of the account to list the sites of. If not specified, the provider account_id or the account identified by the authentication parameters is used.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"domain_contains": {
+				Description: "Only include the sites whose domain contains this string (case insensitive).",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"active": {
+				Description: "Only include the sites in this state (active or bypass).",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					if v := val.(string); v != "active" && v != "bypass" {
+						errs = append(errs, fmt.Errorf("%q must be active or bypass, got: %s", key, v))
+					}
+					return
+				},
+			},
+			"ref_id": {
+				Description: "Only include the sites with this customer specific identifier.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+
+			// Computed Attributes
+			"ids": {
+				Description: "Numeric identifiers of the matching sites.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"sites": {
+				Description: "The matching sites.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"site_id": {
+							Description: "Numeric identifier of the site.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"domain": {
+							Description: "The domain of the site.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"account_id": {
+							Description: "Numeric identifier of the account of the site.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"status": {
+							Description: "The status of the site.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"active": {
+							Description: "Whether the site is active or bypassed (active or bypass).",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"ref_id": {
+							Description: "Customer specific identifier of the site.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"acceleration_level": {
+							Description: "The acceleration level of the site.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSitesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	sites, err := client.ListAllSites(ctx, d.Get("account_id").(int))
+	if err != nil {
+		log.Printf("[ERROR] Could not list Incapsula sites: %s\n", err)
+		return diag.FromErr(err)
+	}
+
+	domainContains := strings.ToLower(d.Get("domain_contains").(string))
+	active := d.Get("active").(string)
+	refID := d.Get("ref_id").(string)
+
+	ids := make([]string, 0)
+	flattened := make([]interface{}, 0)
+	for _, site := range sites {
+		if domainContains != "" && !strings.Contains(strings.ToLower(site.Domain), domainContains) {
+			continue
+		}
+		if active != "" && site.Active != active {
+			continue
+		}
+		if refID != "" && site.RefID != refID {
+			continue
+		}
+
+		ids = append(ids, strconv.Itoa(site.SiteID))
+		flattened = append(flattened, map[string]interface{}{
+			"site_id":            site.SiteID,
+			"domain":             site.Domain,
+			"account_id":         site.AccountID,
+			"status":             site.Status,
+			"active":             site.Active,
+			"ref_id":             site.RefID,
+			"acceleration_level": site.AccelerationLevel,
+		})
+	}
+
+	log.Printf("[INFO] Found %d matching Incapsula sites out of %d\n", len(ids), len(sites))
+
+	// Generate ID
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	d.Set("ids", ids)
+	if err := d.Set("sites", flattened); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package incapsula
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const sitesDataSourceName = "data.incapsula_sites.testacc-terraform-sites"
+
+func TestAccIncapsulaSitesDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIncapsulaSiteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIncapsulaSitesDataSourceConfigBasic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(sitesDataSourceName, "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(sitesDataSourceName, "ids.0", siteResourceName, "id"),
+					resource.TestCheckResourceAttr(sitesDataSourceName, "sites.0.domain", testAccDomain),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIncapsulaSitesDataSourceConfigBasic() string {
+	return testAccCheckIncapsulaSiteConfigBasic(testAccDomain) + fmt.Sprintf(`
+data "incapsula_sites" "testacc-terraform-sites" {
+  domain_contains = "%s"
+  depends_on      = ["%s"]
+}
+`, testAccDomain, siteResourceName)
+}
+
+func TestDataSourceSitesReadFilters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.String() != fmt.Sprintf("/%s", endpointSiteList) {
+			t.Errorf("Should have have hit /%s endpoint. Got: %s", endpointSiteList, req.URL.String())
+		}
+		rw.Write([]byte(`{"sites":[
+			{"site_id":1,"domain":"www.example.com","active":"active","ref_id":"team-a"},
+			{"site_id":2,"domain":"api.Example.com","active":"bypass","ref_id":"team-a"},
+			{"site_id":3,"domain":"www.example.org","active":"active","ref_id":"team-b"},
+			{"site_id":4,"domain":"shop.example.com","active":"active","ref_id":"team-a"}
+		],"res":0}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	d := schema.TestResourceDataRaw(t, dataSourceSites().Schema, map[string]interface{}{
+		"domain_contains": "EXAMPLE.com",
+		"active":          "active",
+		"ref_id":          "team-a",
+	})
+	if diags := dataSourceSitesRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("Should not have received an error, got: %v", diags)
+	}
+
+	ids := d.Get("ids").([]interface{})
+	if len(ids) != 2 || ids[0] != "1" || ids[1] != "4" {
+		t.Errorf("Should have matched sites 1 and 4, got: %v", ids)
+	}
+	if d.Get("sites.1.domain") != "shop.example.com" {
+		t.Errorf("Should have exported the site details, got: %v", d.Get("sites"))
+	}
+}
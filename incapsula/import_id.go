@@ -0,0 +1,33 @@
+package incapsula
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseImportID splits an import ID in the parts of the format (e.g. site_id/rule_id)
+// The ID may end with an extra account_id part to import the objects of another account, e.g. a sub-account
+func parseImportID(id, format string) ([]string, int, error) {
+	parts := len(strings.Split(format, "/"))
+
+	idSlice := strings.Split(id, "/")
+	if len(idSlice) < parts || len(idSlice) > parts+1 {
+		return nil, 0, fmt.Errorf("unexpected format of ID (%q), expected %s or %s/account_id", id, format, format)
+	}
+	for _, part := range idSlice {
+		if part == "" {
+			return nil, 0, fmt.Errorf("unexpected format of ID (%q), expected %s or %s/account_id", id, format, format)
+		}
+	}
+
+	if len(idSlice) == parts {
+		return idSlice, 0, nil
+	}
+
+	accountID, err := strconv.Atoi(idSlice[parts])
+	if err != nil {
+		return nil, 0, fmt.Errorf("unexpected format of account_id (%q) in ID (%q): %s", idSlice[parts], id, err)
+	}
+	return idSlice[:parts], accountID, nil
+}
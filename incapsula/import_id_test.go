@@ -0,0 +1,45 @@
+package incapsula
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseImportID(t *testing.T) {
+	cases := []struct {
+		id        string
+		format    string
+		parts     []string
+		accountID int
+		err       string
+	}{
+		{id: "1234/5678", format: "site_id/rule_id", parts: []string{"1234", "5678"}},
+		{id: "1234/5678/42", format: "site_id/rule_id", parts: []string{"1234", "5678"}, accountID: 42},
+		{id: "1234/api.threats.backdoor/42", format: "site_id/rule_id", parts: []string{"1234", "api.threats.backdoor"}, accountID: 42},
+		{id: "1234/5678/9012", format: "site_id/dc_id/server_id", parts: []string{"1234", "5678", "9012"}},
+		{id: "1234/5678/9012/42", format: "site_id/dc_id/server_id", parts: []string{"1234", "5678", "9012"}, accountID: 42},
+		{id: "1234", format: "site_id/rule_id", err: "unexpected format of ID"},
+		{id: "1234/5678/42/1", format: "site_id/rule_id", err: "unexpected format of ID"},
+		{id: "1234//42", format: "site_id/rule_id", err: "unexpected format of ID"},
+		{id: "1234/5678/", format: "site_id/rule_id", err: "unexpected format of ID"},
+		{id: "1234/5678/foo", format: "site_id/rule_id", err: "unexpected format of account_id"},
+	}
+
+	for _, c := range cases {
+		parts, accountID, err := parseImportID(c.id, c.format)
+		if c.err != "" {
+			if err == nil || !strings.HasPrefix(err.Error(), c.err) {
+				t.Errorf("Should have received an error for ID %s (%s), got: %v", c.id, c.format, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Should not have received an error for ID %s (%s), got: %s", c.id, c.format, err)
+			continue
+		}
+		if !reflect.DeepEqual(parts, c.parts) || accountID != c.accountID {
+			t.Errorf("Should have parsed ID %s (%s) to %v and account %d, got: %v and account %d", c.id, c.format, c.parts, c.accountID, parts, accountID)
+		}
+	}
+}
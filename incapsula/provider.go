@@ -1,6 +1,12 @@
 package incapsula
 
 import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -9,6 +15,17 @@ var baseURLRev2 string
 var baseURLAPI string
 var descriptions map[string]string
 
+// providerVersion is set at build time (-ldflags "-X .../incapsula.providerVersion=x.y.z")
+var providerVersion = "dev"
+
+const defaultRetryMaxAttempts = 4
+const defaultRetryMaxWait = 60
+const defaultRequestTimeout = 60
+const defaultTLSMinVersion = "1.2"
+
+// Default timeout of the resource operations, overridden by the timeouts block of the resources
+const defaultResourceTimeout = 20 * time.Minute
+
 func init() {
 	baseURL = "https://my.incapsula.com/api/prov/v1"
 	baseURLRev2 = "https://my.imperva.com/api/prov/v2"
@@ -23,24 +40,111 @@ func init() {
 			"from the Incapsula management console. Can be set via INCAPSULA_API_KEY " +
 			"environment variable.",
 
+		"shared_credentials_file": "The path to the shared credentials file (INI, or YAML with a .yaml or .yml extension), defaults to ~/.incapsula/credentials.\n" +
+			"Can be set via INCAPSULA_SHARED_CREDENTIALS_FILE environment variable.",
+
+		"profile": "The profile of the shared credentials file to use, defaults to default.\n" +
+			"Can be set via INCAPSULA_PROFILE environment variable.",
+
+		"account_id": "The account to operate on when a resource does not specify one, e.g. a sub-account.\n" +
+			"Can be set via INCAPSULA_ACCOUNT_ID environment variable.",
+
 		"base_url": "The base URL for API operations. Used for provider development.",
 
 		"base_url_rev_2": "The base URL (revision 2) for API operations. Used for provider development.",
 
 		"base_url_api": "The base URL (same as v2 but with different subdomain) for API operations. Used for provider development.",
+
+		"retry_max_attempts": "The maximum number of attempts for a single API request, including the first one.\n" +
+			"Transient errors, throttled requests (429) and server errors (5xx) are retried with exponential backoff. " +
+			"Can be set via INCAPSULA_RETRY_MAX_ATTEMPTS environment variable.",
+
+		"retry_max_wait": "The maximum total time, in seconds, to wait between the attempts of a single API request.\n" +
+			"Can be set via INCAPSULA_RETRY_MAX_WAIT environment variable.",
+
+		"requests_per_second": "The maximum average number of API requests per second, shared by all resources.\n" +
+			"Defaults to 0 (unlimited), must not be negative. Can be set via INCAPSULA_REQUESTS_PER_SECOND environment variable.",
+
+		"max_concurrent_requests": "The maximum number of API requests in flight at the same time, shared by all resources.\n" +
+			"Defaults to 0 (unlimited), must not be negative. Can be set via INCAPSULA_MAX_CONCURRENT_REQUESTS environment variable.",
+
+		"request_timeout": "The timeout, in seconds, of a single API request attempt. 0 disables the timeout.\n" +
+			"Can be set via INCAPSULA_REQUEST_TIMEOUT environment variable.",
+
+		"proxy_url": "The URL of the proxy used for all API requests, e.g. http://proxy.example.com:3128.\n" +
+			"Defaults to the HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables. Can be set via INCAPSULA_PROXY_URL environment variable.",
+
+		"ca_certificate_pem": "PEM encoded CA certificates trusted in addition to the system ones, e.g. the CA of a TLS intercepting proxy.",
+
+		"tls_min_version": "The minimum TLS version (1.0, 1.1, 1.2 or 1.3). Defaults to 1.2.",
+
+		"insecure_skip_verify": "Skip the verification of the Incapsula API server certificate. For testing only.\n" +
+			"Can be set via INCAPSULA_INSECURE_SKIP_VERIFY environment variable.",
+
+		"user_agent_suffix": "A string appended to the User-Agent header of all API requests, e.g. to identify a pipeline.\n" +
+			"Can be set via INCAPSULA_USER_AGENT_SUFFIX environment variable.",
+
+		"skip_credentials_validation": "Skip the validation of the API credentials against the account API when configuring the provider.\n" +
+			"Can be set via INCAPSULA_SKIP_CREDENTIALS_VALIDATION environment variable.",
+
+		"offline": "Defer all credentials checks until the first API call, so that operations without API calls work without credentials.\n" +
+			"Can be set via INCAPSULA_OFFLINE environment variable.",
 	}
 }
 
-func providerConfigure(d *schema.ResourceData, terraformVersion string) (interface{}, error) {
+func providerConfigure(ctx context.Context, d *schema.ResourceData, terraformVersion string) (interface{}, diag.Diagnostics) {
 	config := Config{
 		APIID:       d.Get("api_id").(string),
 		APIKey:      d.Get("api_key").(string),
 		BaseURL:     d.Get("base_url").(string),
 		BaseURLRev2: d.Get("base_url_rev_2").(string),
 		BaseURLAPI:  d.Get("base_url_api").(string),
+
+		SharedCredentialsFile: d.Get("shared_credentials_file").(string),
+		Profile:               d.Get("profile").(string),
+
+		AccountID: d.Get("account_id").(int),
+
+		RetryMaxAttempts: d.Get("retry_max_attempts").(int),
+		RetryMaxWait:     time.Duration(d.Get("retry_max_wait").(int)) * time.Second,
+
+		RequestsPerSecond:     d.Get("requests_per_second").(float64),
+		MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
+
+		RequestTimeout:     time.Duration(d.Get("request_timeout").(int)) * time.Second,
+		ProxyURL:           d.Get("proxy_url").(string),
+		CACertificatePEM:   d.Get("ca_certificate_pem").(string),
+		TLSMinVersion:      d.Get("tls_min_version").(string),
+		InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+
+		UserAgent: userAgent(terraformVersion, d.Get("user_agent_suffix").(string)),
+
+		SkipCredentialsValidation: d.Get("skip_credentials_validation").(bool),
+		Offline:                   d.Get("offline").(bool),
+	}
+
+	// Fill the settings which are not configured explicitly from the shared credentials file
+	if err := config.applyProfile(); err != nil {
+		return nil, diag.FromErr(err)
 	}
 
-	return config.Client()
+	// Base URLs default to the production endpoints
+	if config.BaseURL == "" {
+		config.BaseURL = baseURL
+	}
+	if config.BaseURLRev2 == "" {
+		config.BaseURLRev2 = baseURLRev2
+	}
+	if config.BaseURLAPI == "" {
+		config.BaseURLAPI = baseURLAPI
+	}
+
+	client, err := config.Client(ctx)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	return client, nil
 }
 
 // Provider returns a *schema.Provider.
@@ -56,57 +160,182 @@ func Provider() *schema.Provider {
 			"api_key": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_API_KEY", ""),
 				Description: descriptions["api_key"],
 			},
+			"shared_credentials_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_SHARED_CREDENTIALS_FILE", ""),
+				Description: descriptions["shared_credentials_file"],
+			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_PROFILE", ""),
+				Description: descriptions["profile"],
+			},
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_ACCOUNT_ID", 0),
+				Description: descriptions["account_id"],
+			},
 			"base_url": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_BASE_URL", baseURL),
+				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_BASE_URL", ""),
 				Description: descriptions["base_url"],
 			},
 			"base_url_rev_2": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_BASE_URL_REV_2", baseURLRev2),
+				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_BASE_URL_REV_2", ""),
 				Description: descriptions["base_url_rev_2"],
 			},
 			"base_url_api": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_BASE_URL_API", baseURLAPI),
+				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_BASE_URL_API", ""),
 				Description: descriptions["base_url_api"],
 			},
+			"retry_max_attempts": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_RETRY_MAX_ATTEMPTS", defaultRetryMaxAttempts),
+				Description: descriptions["retry_max_attempts"],
+			},
+			"retry_max_wait": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_RETRY_MAX_WAIT", defaultRetryMaxWait),
+				Description: descriptions["retry_max_wait"],
+			},
+			"requests_per_second": {
+				Type:        schema.TypeFloat,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_REQUESTS_PER_SECOND", 0.0),
+				Description: descriptions["requests_per_second"],
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					if v := val.(float64); v < 0 {
+						errs = append(errs, fmt.Errorf("%q must not be negative (0 is unlimited), got: %g", key, v))
+					}
+					return
+				},
+			},
+			"max_concurrent_requests": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_MAX_CONCURRENT_REQUESTS", 0),
+				Description: descriptions["max_concurrent_requests"],
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					if v := val.(int); v < 0 {
+						errs = append(errs, fmt.Errorf("%q must not be negative (0 is unlimited), got: %d", key, v))
+					}
+					return
+				},
+			},
+			"request_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_REQUEST_TIMEOUT", defaultRequestTimeout),
+				Description: descriptions["request_timeout"],
+			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_PROXY_URL", ""),
+				Description: descriptions["proxy_url"],
+			},
+			"ca_certificate_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: descriptions["ca_certificate_pem"],
+			},
+			"tls_min_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     defaultTLSMinVersion,
+				Description: descriptions["tls_min_version"],
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					version := val.(string)
+					if _, ok := tlsVersions[version]; !ok {
+						errs = append(errs, fmt.Errorf("%q must be one of 1.0, 1.1, 1.2 or 1.3, got: %s", key, version))
+					}
+					return
+				},
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_INSECURE_SKIP_VERIFY", false),
+				Description: descriptions["insecure_skip_verify"],
+			},
+			"user_agent_suffix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_USER_AGENT_SUFFIX", ""),
+				Description: descriptions["user_agent_suffix"],
+			},
+			"skip_credentials_validation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_SKIP_CREDENTIALS_VALIDATION", false),
+				Description: descriptions["skip_credentials_validation"],
+			},
+			"offline": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_OFFLINE", false),
+				Description: descriptions["offline"],
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
+			"incapsula_account":        dataSourceAccount(),
 			"incapsula_role_abilities": dataSourceRoleAbilities(),
+			"incapsula_site":           dataSourceSite(),
+			"incapsula_sites":          dataSourceSites(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"incapsula_acl_security_rule":        resourceACLSecurityRule(),
-			"incapsula_cache_rule":               resourceCacheRule(),
-			"incapsula_custom_certificate":       resourceCertificate(),
-			"incapsula_data_center":              resourceDataCenter(),
-			"incapsula_data_center_server":       resourceDataCenterServer(),
-			"incapsula_incap_rule":               resourceIncapRule(),
-			"incapsula_policy":                   resourcePolicy(),
-			"incapsula_policy_asset_association": resourcePolicyAssetAssociation(),
-			"incapsula_security_rule_exception":  resourceSecurityRuleException(),
-			"incapsula_site":                     resourceSite(),
-			"incapsula_waf_security_rule":        resourceWAFSecurityRule(),
+			"incapsula_acl_security_rule":         resourceACLSecurityRule(),
+			"incapsula_advanced_caching_rules":    resourceAdvancedCachingRules(),
+			"incapsula_cache_purge":               resourceCachePurge(),
+			"incapsula_cache_rule":                resourceCacheRule(),
+			"incapsula_custom_certificate":        resourceCertificate(),
+			"incapsula_data_center":               resourceDataCenter(),
+			"incapsula_data_center_server":        resourceDataCenterServer(),
+			"incapsula_incap_rule":                resourceIncapRule(),
+			"incapsula_policy":                    resourcePolicy(),
+			"incapsula_policy_asset_association":  resourcePolicyAssetAssociation(),
+			"incapsula_security_rule_exception":   resourceSecurityRuleException(),
+			"incapsula_site":                      resourceSite(),
+			"incapsula_site_cache_settings":       resourceSiteCacheSettings(),
+			"incapsula_site_content_optimization": resourceSiteContentOptimization(),
+			"incapsula_waf_security_rule":         resourceWAFSecurityRule(),
 		},
 	}
 
-	provider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+	provider.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		terraformVersion := provider.TerraformVersion
 		if terraformVersion == "" {
 			// Terraform 0.12 introduced this field to the protocol
 			// We can therefore assume that if it's missing it's 0.10 or 0.11
 			terraformVersion = "0.11+compatible"
 		}
-		return providerConfigure(d, terraformVersion)
+		return providerConfigure(ctx, d, terraformVersion)
 	}
 
 	return provider
 }
+
+// userAgent identifies the provider, and the Terraform version running it, in all API requests
+func userAgent(terraformVersion, suffix string) string {
+	userAgent := fmt.Sprintf("terraform-provider-incapsula/%s terraform/%s", providerVersion, terraformVersion)
+	if suffix = strings.TrimSpace(suffix); suffix != "" {
+		userAgent = fmt.Sprintf("%s %s", userAgent, suffix)
+	}
+	return userAgent
+}
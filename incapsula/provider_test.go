@@ -2,6 +2,7 @@ package incapsula
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"sync"
 	"testing"
@@ -31,6 +32,54 @@ func TestProvider_impl(t *testing.T) {
 	var _ *schema.Provider = Provider()
 }
 
+func TestProviderUserAgent(t *testing.T) {
+	expected := fmt.Sprintf("terraform-provider-incapsula/%s terraform/0.14.0", providerVersion)
+	if ua := userAgent("0.14.0", ""); ua != expected {
+		t.Errorf("Should have received %s, got: %s", expected, ua)
+	}
+	if ua := userAgent("0.14.0", " ci-pipeline/42 "); ua != expected+" ci-pipeline/42" {
+		t.Errorf("Should have appended the suffix, got: %s", ua)
+	}
+}
+
+func TestProviderRequestLimitsValidation(t *testing.T) {
+	provider := Provider()
+
+	for key, values := range map[string][]interface{}{
+		"requests_per_second":     {-0.5, 0.0, 2.5},
+		"max_concurrent_requests": {-1, 0, 4},
+	} {
+		validate := provider.Schema[key].ValidateFunc
+		for i, val := range values {
+			_, errs := validate(val, key)
+			// Only the first value of each argument is negative
+			if negative := i == 0; negative != (len(errs) > 0) {
+				t.Errorf("%s = %v: Should have rejected only negative values, got: %v", key, val, errs)
+			}
+		}
+	}
+}
+
+func TestProviderResourceTimeouts(t *testing.T) {
+	resources := Provider().ResourcesMap
+	for _, name := range []string{
+		"incapsula_site",
+		"incapsula_custom_certificate",
+		"incapsula_policy",
+		"incapsula_data_center",
+		"incapsula_incap_rule",
+		"incapsula_acl_security_rule",
+		"incapsula_waf_security_rule",
+		"incapsula_security_rule_exception",
+		"incapsula_cache_rule",
+	} {
+		timeouts := resources[name].Timeouts
+		if timeouts == nil || timeouts.Create == nil || timeouts.Read == nil || timeouts.Update == nil || timeouts.Delete == nil {
+			t.Errorf("%s should declare create, read, update and delete timeouts", name)
+		}
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	testAccProviderConfigure.Do(func() {
 		if v := os.Getenv("INCAPSULA_API_ID"); v == "" {
@@ -0,0 +1,85 @@
+package incapsula
+
+import (
+	"io"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const redactedValue = "[REDACTED]"
+
+// Secrets shorter than this are not masked literally, they would mangle unrelated output
+const minRedactedSecretLength = 8
+
+// Parameter names whose values must never be logged or returned in errors
+var sensitiveParamNames = `api_key|apiKey|x-API-Key|private_key|privateKey|passphrase|hash_salt|hashSalt`
+
+var sensitivePatterns = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	// PEM encoded private keys
+	{regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`), redactedValue},
+	// JSON fields, e.g. "hash_salt":"foo"
+	{regexp.MustCompile(`(?i)("(?:` + sensitiveParamNames + `)"\s*:\s*)"(?:[^"\\]|\\.)*"`), `$1"` + redactedValue + `"`},
+	// Form and query string parameters, e.g. api_key=foo
+	{regexp.MustCompile(`(?i)\b((?:` + sensitiveParamNames + `)=)[^&\s"]+`), `${1}` + redactedValue},
+	// HTTP headers, e.g. x-API-Key: foo
+	{regexp.MustCompile(`(?i)\b(x-API-Key:\s*)\S+`), `${1}` + redactedValue},
+}
+
+// secretRegistry holds the literal secret values known to the provider (API keys, private keys, ...)
+var secretRegistry = struct {
+	sync.RWMutex
+	values map[string]struct{}
+}{values: map[string]struct{}{}}
+
+var installLogRedactionOnce sync.Once
+
+// registerSecret makes sure the value is masked wherever it shows up in logs or errors
+func registerSecret(value string) {
+	value = strings.TrimSpace(value)
+	if len(value) < minRedactedSecretLength {
+		return
+	}
+
+	secretRegistry.Lock()
+	secretRegistry.values[value] = struct{}{}
+	secretRegistry.Unlock()
+}
+
+// redactSecrets masks registered secret values and well known sensitive parameters
+func redactSecrets(s string) string {
+	for _, sensitive := range sensitivePatterns {
+		s = sensitive.pattern.ReplaceAllString(s, sensitive.replacement)
+	}
+
+	secretRegistry.RLock()
+	for value := range secretRegistry.values {
+		s = strings.Replace(s, value, redactedValue, -1)
+	}
+	secretRegistry.RUnlock()
+
+	return s
+}
+
+// redactingWriter masks secrets before handing log lines to the underlying writer
+type redactingWriter struct {
+	out io.Writer
+}
+
+func (w *redactingWriter) Write(p []byte) (int, error) {
+	if _, err := w.out.Write([]byte(redactSecrets(string(p)))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// installLogRedaction routes the standard logger (TF_LOG output) through redactSecrets
+func installLogRedaction() {
+	installLogRedactionOnce.Do(func() {
+		log.SetOutput(&redactingWriter{out: log.Writer()})
+	})
+}
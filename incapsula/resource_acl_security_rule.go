@@ -1,26 +1,28 @@
 package incapsula
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceACLSecurityRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceACLSecurityRuleCreate,
-		Read:   resourceACLSecurityRuleRead,
-		Update: resourceACLSecurityRuleUpdate,
-		Delete: resourceACLSecurityRuleDelete,
+		CreateContext: resourceACLSecurityRuleCreate,
+		ReadContext:   resourceACLSecurityRuleRead,
+		UpdateContext: resourceACLSecurityRuleUpdate,
+		DeleteContext: resourceACLSecurityRuleDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				idSlice := strings.Split(d.Id(), "/")
-				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
-					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id/rule_id", d.Id())
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				idSlice, accountID, err := parseImportID(d.Id(), "site_id/rule_id")
+				if err != nil {
+					return nil, err
 				}
+				d.Set("account_id", accountID)
 
 				siteID, err := strconv.Atoi(idSlice[0])
 				ruleID := idSlice[1]
@@ -34,6 +36,13 @@ func resourceACLSecurityRule() *schema.Resource {
 			},
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+
 		Schema: map[string]*schema.Schema{
 			// Required Arguments
 			"site_id": {
@@ -48,6 +57,13 @@ func resourceACLSecurityRule() *schema.Resource {
 			},
 
 			// Optional Arguments
+			"account_id": {
+				Description: "Numeric identifier of the account to operate on. If not specified, the provider account_id is used.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
 			"continents": {
 				Description:      "A comma separated list of continent codes.",
 				Type:             schema.TypeString,
@@ -88,7 +104,7 @@ func resourceACLSecurityRule() *schema.Resource {
 	}
 }
 
-func resourceACLSecurityRuleCreate(d *schema.ResourceData, m interface{}) error {
+func resourceACLSecurityRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	ruleID := d.Get("rule_id").(string)
@@ -96,6 +112,7 @@ func resourceACLSecurityRuleCreate(d *schema.ResourceData, m interface{}) error
 	log.Printf("[INFO] Creating Incapsula ACL Rule for id: %s\n", ruleID)
 
 	_, err := client.ConfigureACLSecurityRule(
+		ctx,
 		d.Get("site_id").(int),
 		ruleID,
 		d.Get("continents").(string),
@@ -103,11 +120,12 @@ func resourceACLSecurityRuleCreate(d *schema.ResourceData, m interface{}) error
 		d.Get("ips").(string),
 		d.Get("urls").(string),
 		d.Get("url_patterns").(string),
+		d.Get("account_id").(int),
 	)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not create Incapsula ACL Rule for id: %s, %s\n", ruleID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the rule ID
@@ -115,10 +133,10 @@ func resourceACLSecurityRuleCreate(d *schema.ResourceData, m interface{}) error
 
 	log.Printf("[INFO] Created Incapsula ACL Rule for id: %s\n", ruleID)
 
-	return resourceACLSecurityRuleRead(d, m)
+	return resourceACLSecurityRuleRead(ctx, d, m)
 }
 
-func resourceACLSecurityRuleRead(d *schema.ResourceData, m interface{}) error {
+func resourceACLSecurityRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by reading the SiteResponse for the site
 	client := m.(*Client)
 
@@ -126,10 +144,10 @@ func resourceACLSecurityRuleRead(d *schema.ResourceData, m interface{}) error {
 
 	log.Printf("[INFO] Reading Incapsula ACL Rule for id: %s\n", ruleID)
 
-	siteStatusResponse, err := client.SiteStatus("acl-rule-read", d.Get("site_id").(int))
+	siteStatusResponse, err := client.SiteStatus(ctx, "acl-rule-read", d.Get("site_id").(int), d.Get("account_id").(int))
 
 	// Site object may have been deleted
-	if siteStatusResponse != nil && siteStatusResponse.Res.(float64) == 9413 {
+	if IsNotFound(err) {
 		log.Printf("[INFO] Incapsula Site ID %s has already been deleted: %s\n", d.Get("site_id"), err)
 		d.SetId("")
 		return nil
@@ -137,7 +155,7 @@ func resourceACLSecurityRuleRead(d *schema.ResourceData, m interface{}) error {
 
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula ACL Rule for id: %s, %s\n", ruleID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	found := false
@@ -175,17 +193,19 @@ func resourceACLSecurityRuleRead(d *schema.ResourceData, m interface{}) error {
 		return nil
 	}
 
+	d.Set("account_id", client.accountID(d.Get("account_id").(int)))
+
 	log.Printf("[INFO] Read Incapsula ACL Rule for id: %s\n", ruleID)
 
 	return nil
 }
 
-func resourceACLSecurityRuleUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceACLSecurityRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// This is the same as create
-	return resourceACLSecurityRuleCreate(d, m)
+	return resourceACLSecurityRuleCreate(ctx, d, m)
 }
 
-func resourceACLSecurityRuleDelete(d *schema.ResourceData, m interface{}) error {
+func resourceACLSecurityRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	ruleID := d.Get("rule_id").(string)
@@ -194,6 +214,7 @@ func resourceACLSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 
 	// Implement delete by clearing out the rule configuration
 	_, err := client.ConfigureACLSecurityRule(
+		ctx,
 		d.Get("site_id").(int),
 		ruleID,
 		"", // countries
@@ -201,11 +222,12 @@ func resourceACLSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 		"", // ips
 		"", // urls
 		"", // urls
+		d.Get("account_id").(int),
 	)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not delete Incapsula ACL Rule for id: %s, %s\n", ruleID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
@@ -0,0 +1,289 @@
+package incapsula
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Patterns of the advanced caching rules
+var advancedCachingRulePatterns = []string{"contains", "equals", "prefix", "suffix", "not_contains", "not_equals", "not_prefix", "not_suffix"}
+
+func resourceAdvancedCachingRules() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAdvancedCachingRulesCreate,
+		ReadContext:   resourceAdvancedCachingRulesRead,
+		UpdateContext: resourceAdvancedCachingRulesUpdate,
+		DeleteContext: resourceAdvancedCachingRulesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				idSlice, accountID, err := parseImportID(d.Id(), "site_id")
+				if err != nil {
+					return nil, err
+				}
+				d.Set("account_id", accountID)
+
+				siteID := idSlice[0]
+				d.Set("site_id", siteID)
+				d.SetId(siteID)
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			// Required Arguments
+			"site_id": {
+				Description: "Numeric identifier of the site to operate on.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			// Optional Arguments
+			"account_id": {
+				Description: "Numeric identifier of the account to operate on. If not specified, the provider account_id is used.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"always_cache": {
+				Description: "Resources to always cache.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": {
+							Description:  "The URL (or part of the URL) of the resources.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateAdvancedCachingRuleURL,
+						},
+						"pattern": {
+							Description:  "How the URL is matched. Options are `contains`, `equals`, `prefix`, `suffix`, `not_contains`, `not_equals`, `not_prefix` and `not_suffix`.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateAdvancedCachingRulePattern,
+						},
+						"ttl": {
+							Description: "The time, in seconds, to cache the resources.",
+							Type:        schema.TypeInt,
+							Required:    true,
+							ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+								if v := val.(int); v < 1 {
+									errs = append(errs, fmt.Errorf("%q must be at least 1, got: %d", key, v))
+								}
+								return
+							},
+						},
+					},
+				},
+			},
+			"never_cache": {
+				Description: "Resources to never cache.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": {
+							Description:  "The URL (or part of the URL) of the resources.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateAdvancedCachingRuleURL,
+						},
+						"pattern": {
+							Description:  "How the URL is matched. Options are `contains`, `equals`, `prefix`, `suffix`, `not_contains`, `not_equals`, `not_prefix` and `not_suffix`.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateAdvancedCachingRulePattern,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func validateAdvancedCachingRuleURL(val interface{}, key string) (warns []string, errs []error) {
+	// The rules are sent as comma separated values
+	if v := val.(string); strings.Contains(v, ",") {
+		errs = append(errs, fmt.Errorf("%q must not contain a comma, got: %s", key, v))
+	}
+	return
+}
+
+func validateAdvancedCachingRulePattern(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	for _, pattern := range advancedCachingRulePatterns {
+		if v == pattern {
+			return
+		}
+	}
+	errs = append(errs, fmt.Errorf("%q must be one of %s, got: %s", key, strings.Join(advancedCachingRulePatterns, ", "), v))
+	return
+}
+
+func resourceAdvancedCachingRulesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// The rules are a setting of the site, so the site ID is the resource ID
+	d.SetId(d.Get("site_id").(string))
+
+	diags := resourceAdvancedCachingRulesUpdate(ctx, d, m)
+	if diags.HasError() {
+		d.SetId("")
+	}
+	return diags
+}
+
+func resourceAdvancedCachingRulesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	siteID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("Error parsing site ID %s: %s", d.Id(), err))
+	}
+
+	log.Printf("[INFO] Reading Incapsula advanced caching rules for site_id: %s\n", d.Id())
+
+	siteStatusResponse, err := client.SiteStatus(ctx, "advanced-caching-rules-read", siteID, d.Get("account_id").(int))
+
+	// Site object may have been deleted
+	if IsNotFound(err) {
+		log.Printf("[INFO] Incapsula Site ID %d has already been deleted: %s\n", siteID, err)
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		log.Printf("[ERROR] Could not read Incapsula advanced caching rules for site_id: %s, %s\n", d.Id(), err)
+		return diag.FromErr(err)
+	}
+
+	advancedCachingRules := siteStatusResponse.PerformanceConfiguration.AdvancedCachingRules
+
+	alwaysCacheRules, err := parseAdvancedCachingRules(advancedCachingRules.AlwaysCacheResources)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	alwaysCache := make([]interface{}, 0, len(alwaysCacheRules))
+	for _, rule := range alwaysCacheRules {
+		ttl, err := rule.TTLSeconds()
+		if err != nil {
+			// Don't fail the read on units this provider doesn't know, keep the TTL of the state instead
+			// Without a rule in the state (e.g. on import), the TTL in seconds can't be known
+			stateTTL, ok := stateAdvancedCachingRuleTTL(d, rule)
+			if !ok {
+				return diag.FromErr(fmt.Errorf("Error reading Incapsula advanced caching rules for site_id: %s: %s, and the state has no TTL to keep for it", d.Id(), err))
+			}
+			log.Printf("[WARN] %s, keeping the TTL (%d) of the state for site_id: %s\n", err, stateTTL, d.Id())
+			ttl = stateTTL
+		}
+		alwaysCache = append(alwaysCache, map[string]interface{}{
+			"url":     rule.URL,
+			"pattern": rule.Pattern,
+			"ttl":     ttl,
+		})
+	}
+
+	neverCacheRules, err := parseAdvancedCachingRules(advancedCachingRules.NeverCacheResources)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	neverCache := make([]interface{}, 0, len(neverCacheRules))
+	for _, rule := range neverCacheRules {
+		neverCache = append(neverCache, map[string]interface{}{
+			"url":     rule.URL,
+			"pattern": rule.Pattern,
+		})
+	}
+
+	d.Set("site_id", d.Id())
+	d.Set("account_id", client.accountID(d.Get("account_id").(int)))
+	if err := d.Set("always_cache", alwaysCache); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("never_cache", neverCache); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Finished reading Incapsula advanced caching rules for site_id: %s\n", d.Id())
+
+	return nil
+}
+
+// stateAdvancedCachingRuleTTL returns the TTL of the always cache rule with the same URL and pattern in the state
+func stateAdvancedCachingRuleTTL(d *schema.ResourceData, rule AdvancedCachingRule) (int, bool) {
+	for _, stateRule := range d.Get("always_cache").([]interface{}) {
+		stateRule := stateRule.(map[string]interface{})
+		if stateRule["url"].(string) == rule.URL && stateRule["pattern"].(string) == rule.Pattern {
+			return stateRule["ttl"].(int), true
+		}
+	}
+	return 0, false
+}
+
+func resourceAdvancedCachingRulesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	alwaysCacheRules := make([]AdvancedCachingRule, 0)
+	for _, rule := range d.Get("always_cache").([]interface{}) {
+		rule := rule.(map[string]interface{})
+		alwaysCacheRules = append(alwaysCacheRules, AdvancedCachingRule{
+			URL:     rule["url"].(string),
+			Pattern: rule["pattern"].(string),
+			TTL:     rule["ttl"].(int),
+		})
+	}
+
+	neverCacheRules := make([]AdvancedCachingRule, 0)
+	for _, rule := range d.Get("never_cache").([]interface{}) {
+		rule := rule.(map[string]interface{})
+		neverCacheRules = append(neverCacheRules, AdvancedCachingRule{
+			URL:     rule["url"].(string),
+			Pattern: rule["pattern"].(string),
+		})
+	}
+
+	err := client.UpdateAdvancedCachingRules(ctx, d.Id(), alwaysCacheRules, neverCacheRules, d.Get("account_id").(int))
+	if err != nil {
+		log.Printf("[ERROR] Could not update Incapsula advanced caching rules for site_id: %s, %s\n", d.Id(), err)
+		return diag.FromErr(err)
+	}
+
+	// Set the rest of the state from the resource read
+	return resourceAdvancedCachingRulesRead(ctx, d, m)
+}
+
+func resourceAdvancedCachingRulesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	siteID := d.Id()
+
+	log.Printf("[INFO] Clearing Incapsula advanced caching rules for site_id: %s\n", siteID)
+
+	err := client.UpdateAdvancedCachingRules(ctx, siteID, nil, nil, d.Get("account_id").(int))
+
+	if err != nil && !IsNotFound(err) {
+		log.Printf("[ERROR] Could not clear Incapsula advanced caching rules for site_id: %s, %s\n", siteID, err)
+		return diag.FromErr(err)
+	}
+
+	// Set the ID to empty
+	// Implicitly clears the resource
+	d.SetId("")
+
+	log.Printf("[INFO] Cleared Incapsula advanced caching rules for site_id: %s\n", siteID)
+
+	return nil
+}
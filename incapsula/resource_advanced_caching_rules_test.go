@@ -0,0 +1,165 @@
+package incapsula
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const advancedCachingRulesResourceName = "incapsula_advanced_caching_rules.testacc-terraform-advanced-caching-rules"
+
+func TestAccIncapsulaAdvancedCachingRules_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIncapsulaSiteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIncapsulaAdvancedCachingRulesConfigBasic(),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckIncapsulaAdvancedCachingRulesExists(advancedCachingRulesResourceName),
+					resource.TestCheckResourceAttr(advancedCachingRulesResourceName, "always_cache.#", "1"),
+					resource.TestCheckResourceAttr(advancedCachingRulesResourceName, "always_cache.0.ttl", "3600"),
+					resource.TestCheckResourceAttr(advancedCachingRulesResourceName, "never_cache.0.url", "/admin"),
+				),
+			},
+			{
+				ResourceName:      advancedCachingRulesResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckIncapsulaAdvancedCachingRulesExists(name string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		res, ok := state.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Incapsula Advanced Caching Rules resource not found: %s", name)
+		}
+
+		siteID, err := strconv.Atoi(res.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Error parsing ID %v to int", res.Primary.ID)
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		siteStatusResponse, err := client.SiteStatus(context.Background(), "advanced-caching-rules-test", siteID, 0)
+		if err != nil {
+			return fmt.Errorf("Incapsula site %d does not exist: %s", siteID, err)
+		}
+		if len(siteStatusResponse.PerformanceConfiguration.AdvancedCachingRules.AlwaysCacheResources) == 0 {
+			return fmt.Errorf("Incapsula Advanced Caching Rules: %s (site id: %d) does not exist", name, siteID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckIncapsulaAdvancedCachingRulesConfigBasic() string {
+	return testAccCheckIncapsulaSiteConfigBasic(testAccDomain) + fmt.Sprintf(`
+resource "incapsula_advanced_caching_rules" "testacc-terraform-advanced-caching-rules" {
+  site_id = "${incapsula_site.testacc-terraform-site.id}"
+  always_cache {
+    url     = "/static"
+    pattern = "prefix"
+    ttl     = 3600
+  }
+  never_cache {
+    url     = "/admin"
+    pattern = "prefix"
+  }
+  depends_on = ["%s"]
+}`, siteResourceName,
+	)
+}
+
+func TestResourceAdvancedCachingRulesReadUnknownTTLUnits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.String() != fmt.Sprintf("/%s", endpointSiteStatus) {
+			t.Errorf("Should have have hit /%s endpoint. Got: %s", endpointSiteStatus, req.URL.String())
+		}
+		rw.Write([]byte(`{"res":0,"site_id":42,"performance_configuration":{"advanced_caching_rules":{"always_cache_resources":[{"pattern":"prefix","url":"/static","ttl":2,"ttlUnits":"FORTNIGHTS"},{"pattern":"suffix","url":".jpg","ttl":1,"ttlUnits":"HOUR"}],"never_cache_resources":[]}}}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	d := schema.TestResourceDataRaw(t, resourceAdvancedCachingRules().Schema, map[string]interface{}{
+		"site_id": "42",
+		"always_cache": []interface{}{
+			map[string]interface{}{"url": "/static", "pattern": "prefix", "ttl": 2419200},
+			map[string]interface{}{"url": ".jpg", "pattern": "suffix", "ttl": 3600},
+		},
+	})
+	d.SetId("42")
+
+	if diags := resourceAdvancedCachingRulesRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("Should not have received an error, got: %+v", diags)
+	}
+	if ttl := d.Get("always_cache.0.ttl").(int); ttl != 2419200 {
+		t.Errorf("Should have kept the TTL of the state for unknown units, got: %d", ttl)
+	}
+	if ttl := d.Get("always_cache.1.ttl").(int); ttl != 3600 {
+		t.Errorf("Should have converted the TTL of the singular units, got: %d", ttl)
+	}
+}
+
+func TestResourceAdvancedCachingRulesReadUnknownTTLUnitsNotInState(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"res":0,"site_id":42,"performance_configuration":{"advanced_caching_rules":{"always_cache_resources":[{"pattern":"prefix","url":"/static","ttl":2,"ttlUnits":"FORTNIGHTS"}],"never_cache_resources":[]}}}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	// e.g. right after an import, there is no TTL in the state to keep
+	d := schema.TestResourceDataRaw(t, resourceAdvancedCachingRules().Schema, map[string]interface{}{"site_id": "42"})
+	d.SetId("42")
+
+	diags := resourceAdvancedCachingRulesRead(context.Background(), d, client)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "Unknown TTL units (FORTNIGHTS)") {
+		t.Errorf("Should have received an unknown TTL units error, got: %+v", diags)
+	}
+}
+
+func TestResourceAdvancedCachingRulesAccountID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if accountID := req.FormValue("account_id"); accountID != "5678" {
+			t.Errorf("Should have sent the account_id to %s, got: %s", req.URL.Path, accountID)
+		}
+		rw.Write([]byte(`{"res":0,"site_id":42}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL, AccountID: 1234}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	// The account is imported from the site_id/account_id ID, then used by the read and the update
+	d := resourceAdvancedCachingRules().TestResourceData()
+	d.SetId("42/5678")
+	if _, err := resourceAdvancedCachingRules().Importer.StateContext(context.Background(), d, client); err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if d.Id() != "42" || d.Get("site_id").(string) != "42" {
+		t.Errorf("Should have imported site 42, got: %s", d.Id())
+	}
+
+	if diags := resourceAdvancedCachingRulesUpdate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("Should not have received an error, got: %+v", diags)
+	}
+	if accountID := d.Get("account_id").(int); accountID != 5678 {
+		t.Errorf("Should have kept the account_id 5678, got: %d", accountID)
+	}
+}
@@ -0,0 +1,110 @@
+package incapsula
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCachePurge() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCachePurgeCreate,
+		ReadContext:   resourceCachePurgeRead,
+		DeleteContext: resourceCachePurgeDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+
+		// Every argument forces a new resource, so that any change purges the cache again
+		Schema: map[string]*schema.Schema{
+			// Required Arguments
+			"site_id": {
+				Description: "Numeric identifier of the site to purge the cache of.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			// Optional Arguments
+			"account_id": {
+				Description: "Numeric identifier of the account to operate on. If not specified, the provider account_id is used.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"purge_pattern": {
+				Description:   "Only purge the resources matching this pattern, e.g. images (contains), ^/images (starts with), .jpg$ (ends with) or ^/index.html$ (exact match).",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"tag_names"},
+			},
+			"tag_names": {
+				Description:   "Only purge the resources tagged with any of these cache tags.",
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				MinItems:      1,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"purge_pattern"},
+			},
+			"triggers": {
+				Description: "Arbitrary values which purge the cache again when they change, e.g. the version of the deployed application.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceCachePurgeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	siteID := d.Get("site_id").(string)
+	accountID := d.Get("account_id").(int)
+
+	var err error
+	if pattern := d.Get("purge_pattern").(string); pattern != "" {
+		err = client.PurgeCacheResources(ctx, siteID, pattern, accountID)
+	} else if tagNames := d.Get("tag_names").([]interface{}); len(tagNames) > 0 {
+		tags := make([]string, 0, len(tagNames))
+		for _, tagName := range tagNames {
+			tags = append(tags, tagName.(string))
+		}
+		err = client.PurgeCacheTags(ctx, siteID, tags, accountID)
+	} else {
+		err = client.PurgeSiteCache(ctx, siteID, accountID)
+	}
+
+	if err != nil {
+		log.Printf("[ERROR] Could not purge Incapsula cache for site_id: %s, %s\n", siteID, err)
+		return diag.FromErr(err)
+	}
+
+	// Generate ID
+	d.SetId(strconv.FormatInt(time.Now().UnixNano(), 10))
+	d.Set("account_id", client.accountID(accountID))
+
+	log.Printf("[INFO] Purged Incapsula cache for site_id: %s\n", siteID)
+
+	return nil
+}
+
+func resourceCachePurgeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// A purge is an action, there is nothing to read back
+	return nil
+}
+
+func resourceCachePurgeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// A purge can't be undone, the resource is only removed from the state
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package incapsula
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const cachePurgeResourceName = "incapsula_cache_purge.testacc-terraform-cache-purge"
+
+func TestAccIncapsulaCachePurge_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIncapsulaSiteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIncapsulaCachePurgeConfigBasic("1.0.0"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(cachePurgeResourceName, "id"),
+					resource.TestCheckResourceAttr(cachePurgeResourceName, "triggers.version", "1.0.0"),
+				),
+			},
+			{
+				Config: testAccCheckIncapsulaCachePurgeConfigBasic("1.0.1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(cachePurgeResourceName, "triggers.version", "1.0.1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIncapsulaCachePurgeConfigBasic(version string) string {
+	return testAccCheckIncapsulaSiteConfigBasic(testAccDomain) + fmt.Sprintf(`
+resource "incapsula_cache_purge" "testacc-terraform-cache-purge" {
+  site_id       = "${incapsula_site.testacc-terraform-site.id}"
+  purge_pattern = "^/static"
+  triggers = {
+    version = "%s"
+  }
+  depends_on = ["%s"]
+}`, version, siteResourceName,
+	)
+}
+
+func TestResourceCachePurgeCreate(t *testing.T) {
+	for name, test := range map[string]struct {
+		config   map[string]interface{}
+		expected string
+	}{
+		"site":    {map[string]interface{}{"site_id": "42"}, "site_id=42"},
+		"pattern": {map[string]interface{}{"site_id": "42", "purge_pattern": "^/static"}, "purge_pattern=%5E%2Fstatic&site_id=42"},
+		"tags":    {map[string]interface{}{"site_id": "42", "tag_names": []interface{}{"a", "b"}}, "site_id=42&tag_names=a%2Cb"},
+		"account": {map[string]interface{}{"site_id": "42", "account_id": 5678}, "account_id=5678&site_id=42"},
+	} {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			requests++
+			if req.URL.Path != "/"+endpointCachePurge {
+				t.Errorf("%s: Should have have hit /%s endpoint. Got: %s", name, endpointCachePurge, req.URL.String())
+			}
+			req.ParseForm()
+			req.PostForm.Del("api_id")
+			req.PostForm.Del("api_key")
+			if form := req.PostForm.Encode(); form != test.expected {
+				t.Errorf("%s: Should have sent %s, got: %s", name, test.expected, form)
+			}
+			rw.Write([]byte(`{"res":0,"res_message":"OK"}`))
+		}))
+
+		config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+		client := &Client{config: config, httpClient: &http.Client{}}
+
+		d := schema.TestResourceDataRaw(t, resourceCachePurge().Schema, test.config)
+		if diags := resourceCachePurgeCreate(context.Background(), d, client); diags.HasError() {
+			t.Errorf("%s: Should not have received an error, got: %+v", name, diags)
+		}
+		if requests != 1 {
+			t.Errorf("%s: Should have purged the cache once, got: %d", name, requests)
+		}
+		if d.Id() == "" {
+			t.Errorf("%s: Should have set an ID", name)
+		}
+
+		server.Close()
+	}
+}
@@ -1,25 +1,26 @@
 package incapsula
 
 import (
-	"fmt"
+	"context"
 	"strconv"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceCacheRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCacheRuleCreate,
-		Read:   resourceCacheRuleRead,
-		Update: resourceCacheRuleUpdate,
-		Delete: resourceCacheRuleDelete,
+		CreateContext: resourceCacheRuleCreate,
+		ReadContext:   resourceCacheRuleRead,
+		UpdateContext: resourceCacheRuleUpdate,
+		DeleteContext: resourceCacheRuleDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				idSlice := strings.Split(d.Id(), "/")
-				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
-					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id/rule_id", d.Id())
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				idSlice, accountID, err := parseImportID(d.Id(), "site_id/rule_id")
+				if err != nil {
+					return nil, err
 				}
+				d.Set("account_id", accountID)
 
 				siteID := idSlice[0]
 				d.Set("site_id", siteID)
@@ -31,6 +32,13 @@ func resourceCacheRule() *schema.Resource {
 			},
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+
 		Schema: map[string]*schema.Schema{
 			// Required Arguments
 			"site_id": {
@@ -59,6 +67,13 @@ func resourceCacheRule() *schema.Resource {
 				Required:    true,
 			},
 			// Optional Arguments
+			"account_id": {
+				Description: "Numeric identifier of the account to operate on. If not specified, the provider account_id is used.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
 			"ttl": {
 				Description: "TTL in seconds. Relevant for `HTTP_CACHE_MAKE_STATIC` and `HTTP_CACHE_CLIENT_CACHE_CTL` actions.",
 				Type:        schema.TypeInt,
@@ -83,7 +98,7 @@ func resourceCacheRule() *schema.Resource {
 	}
 }
 
-func resourceCacheRuleCreate(d *schema.ResourceData, m interface{}) error {
+func resourceCacheRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	rule := CacheRule{
@@ -97,36 +112,36 @@ func resourceCacheRuleCreate(d *schema.ResourceData, m interface{}) error {
 		DifferentiateByValue: d.Get("differentiate_by_value").(string),
 	}
 
-	ruleWithID, err := client.AddCacheRule(d.Get("site_id").(string), &rule)
+	ruleWithID, err := client.AddCacheRule(ctx, d.Get("site_id").(string), &rule, d.Get("account_id").(int))
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(strconv.Itoa(ruleWithID.RuleID))
 
-	return resourceCacheRuleRead(d, m)
+	return resourceCacheRuleRead(ctx, d, m)
 }
 
-func resourceCacheRuleRead(d *schema.ResourceData, m interface{}) error {
+func resourceCacheRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by reading the SiteResponse for the site
 	client := m.(*Client)
 
 	ruleID, err := strconv.Atoi(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	rule, statusCode, err := client.ReadCacheRule(d.Get("site_id").(string), ruleID)
+	rule, err := client.ReadCacheRule(ctx, d.Get("site_id").(string), ruleID, d.Get("account_id").(int))
 
 	// If the rule is deleted on the server, blow it out locally and run through the normal TF cycle
-	if statusCode == 404 {
+	if IsNotFound(err) {
 		d.SetId("")
 		return nil
 	}
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Update all of the properties
@@ -138,11 +153,12 @@ func resourceCacheRuleRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("ignored_params", rule.IgnoredParams)
 	d.Set("text", rule.Text)
 	d.Set("differentiate_by_value", rule.DifferentiateByValue)
+	d.Set("account_id", client.accountID(d.Get("account_id").(int)))
 
 	return nil
 }
 
-func resourceCacheRuleUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceCacheRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	rule := CacheRule{
@@ -158,29 +174,29 @@ func resourceCacheRuleUpdate(d *schema.ResourceData, m interface{}) error {
 
 	ruleID, err := strconv.Atoi(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = client.UpdateCacheRule(d.Get("site_id").(string), ruleID, &rule)
+	err = client.UpdateCacheRule(ctx, d.Get("site_id").(string), ruleID, &rule, d.Get("account_id").(int))
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceCacheRuleRead(d, m)
+	return resourceCacheRuleRead(ctx, d, m)
 }
 
-func resourceCacheRuleDelete(d *schema.ResourceData, m interface{}) error {
+func resourceCacheRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	ruleID, err := strconv.Atoi(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = client.DeleteCacheRule(d.Get("site_id").(string), ruleID)
+	err = client.DeleteCacheRule(ctx, d.Get("site_id").(string), ruleID, d.Get("account_id").(int))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"testing"
@@ -72,13 +73,13 @@ func testAccCheckIncapsulaCacheRuleDestroy(state *terraform.State) error {
 			return fmt.Errorf("Incapsula Site ID does not exist for Cache Rule ID %d", ruleID)
 		}
 
-		_, statusCode, err := client.ReadCacheRule(siteID, ruleID)
-		if statusCode != 404 {
-			return fmt.Errorf("Incapsula Cache Rule %d (site id: %s) should have received 404 status code", ruleID, siteID)
-		}
+		_, err = client.ReadCacheRule(context.Background(), siteID, ruleID, 0)
 		if err == nil {
 			return fmt.Errorf("Incapsula Cache Rule %d still exists for Site ID %s", ruleID, siteID)
 		}
+		if !IsNotFound(err) {
+			return fmt.Errorf("Incapsula Cache Rule %d (site id: %s) should have received a not found error, got: %s", ruleID, siteID, err)
+		}
 	}
 
 	return nil
@@ -102,10 +103,7 @@ func testCheckIncapsulaCacheRuleExists(name string) resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		_, statusCode, err := client.ReadCacheRule(siteID, ruleID)
-		if statusCode != 200 {
-			return fmt.Errorf("Incapsula Cache Rule: %s (site id: %s) should have received 200 status code", name, siteID)
-		}
+		_, err = client.ReadCacheRule(context.Background(), siteID, ruleID, 0)
 		if err != nil {
 			return fmt.Errorf("Incapsula Cache Rule: %s (site id: %s) does not exist", name, siteID)
 		}
@@ -1,24 +1,34 @@
 package incapsula
 
 import (
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceCertificate() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCertificateCreate,
-		Read:   resourceCertificateRead,
-		Update: resourceCertificateUpdate,
-		Delete: resourceCertificateDelete,
+		CreateContext: resourceCertificateCreate,
+		ReadContext:   resourceCertificateRead,
+		UpdateContext: resourceCertificateUpdate,
+		DeleteContext: resourceCertificateDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				d.SetId("12345")
 				d.Set("site_id", d.Get("site_id").(string))
 				return []*schema.ResourceData{d}, nil
 			},
 		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+
 		Schema: map[string]*schema.Schema{
 			// Required Arguments
 			"site_id": {
@@ -33,6 +43,13 @@ func resourceCertificate() *schema.Resource {
 				ForceNew:    true,
 			},
 			// Optional Arguments
+			"account_id": {
+				Description: "Numeric identifier of the account to operate on. If not specified, the provider account_id is used.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
 			"private_key": {
 				Description: "The private key of the certificate in base64 format. Optional in case of PFX certificate file format. This will be encoded in sha256 in terraform state.",
 				Type:        schema.TypeString,
@@ -51,36 +68,38 @@ func resourceCertificate() *schema.Resource {
 	}
 }
 
-func resourceCertificateCreate(d *schema.ResourceData, m interface{}) error {
+func resourceCertificateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	_, err := client.AddCertificate(
+		ctx,
 		d.Get("site_id").(string),
 		d.Get("certificate").(string),
 		d.Get("private_key").(string),
 		d.Get("passphrase").(string),
+		d.Get("account_id").(int),
 	)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// TODO: Setting this to arbitrary value as there is only one cert for each site.
 	d.SetId("12345")
 
-	return resourceCertificateRead(d, m)
+	return resourceCertificateRead(ctx, d, m)
 }
 
-func resourceCertificateRead(d *schema.ResourceData, m interface{}) error {
+func resourceCertificateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by reading the ListCertificatesResponse for the data center
 	client := m.(*Client)
 
 	siteID := d.Get("site_id").(string)
 
-	listCertificatesResponse, err := client.ListCertificates(siteID)
+	_, err := client.ListCertificates(ctx, siteID, d.Get("account_id").(int))
 
-	// List data centers response object may indicate that the Site ID has been deleted (9413)
-	if listCertificatesResponse != nil && listCertificatesResponse.Res == 9413 {
+	// Site object may have been deleted
+	if IsNotFound(err) {
 		log.Printf("[INFO] Incapsula Site ID %s has already been deleted: %s\n", d.Get("site_id"), err)
 		d.SetId("")
 		return nil
@@ -88,26 +107,29 @@ func resourceCertificateRead(d *schema.ResourceData, m interface{}) error {
 
 	if err != nil {
 		log.Printf("[ERROR] Could not read custom certificate from Incapsula site for site_id: %s, %s\n", siteID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId("12345")
+	d.Set("account_id", client.accountID(d.Get("account_id").(int)))
 
 	return nil
 }
 
-func resourceCertificateUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceCertificateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	_, err := client.EditCertificate(
+		ctx,
 		d.Get("site_id").(string),
 		d.Get("certificate").(string),
 		d.Get("private_key").(string),
 		d.Get("passphrase").(string),
+		d.Get("account_id").(int),
 	)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId("12345")
@@ -115,13 +137,13 @@ func resourceCertificateUpdate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceCertificateDelete(d *schema.ResourceData, m interface{}) error {
+func resourceCertificateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
-	err := client.DeleteCertificate(d.Get("site_id").(string))
+	err := client.DeleteCertificate(ctx, d.Get("site_id").(string), d.Get("account_id").(int))
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
@@ -185,7 +185,7 @@ func testAccCheckIncapsulaCertificateDestroy(state *terraform.State) error {
 
 		err := "nil"
 		// TODO: Update function to look for cert details on site object from ListCertificates when fix is in place in API
-		//listCertificatesResponse, err := client.ListCertificates(siteID)
+		//listCertificatesResponse, err := client.ListCertificates(siteID, 0)
 		//for _, dc := range listCertificatesResponse.Res {
 		//	if dc.Name == certificateName {
 		//		return fmt.Errorf("Incapsula custom certificate: %s (site_id: %s) still exists", certificateName, siteID)
@@ -1,25 +1,26 @@
 package incapsula
 
 import (
-	"fmt"
+	"context"
 	"log"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceDataCenter() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDataCenterCreate,
-		Read:   resourceDataCenterRead,
-		Update: resourceDataCenterUpdate,
-		Delete: resourceDataCenterDelete,
+		CreateContext: resourceDataCenterCreate,
+		ReadContext:   resourceDataCenterRead,
+		UpdateContext: resourceDataCenterUpdate,
+		DeleteContext: resourceDataCenterDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				idSlice := strings.Split(d.Id(), "/")
-				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
-					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id/dc_id", d.Id())
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				idSlice, accountID, err := parseImportID(d.Id(), "site_id/dc_id")
+				if err != nil {
+					return nil, err
 				}
+				d.Set("account_id", accountID)
 
 				siteID := idSlice[0]
 				dcID := idSlice[1]
@@ -30,6 +31,13 @@ func resourceDataCenter() *schema.Resource {
 			},
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+
 		Schema: map[string]*schema.Schema{
 			// Required Arguments
 			"site_id": {
@@ -49,6 +57,13 @@ func resourceDataCenter() *schema.Resource {
 			},
 
 			// Optional Arguments
+			"account_id": {
+				Description: "Numeric identifier of the account to operate on. If not specified, the provider account_id is used.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
 			"is_enabled": {
 				Description: "Enables the data center.",
 				Type:        schema.TypeString,
@@ -64,61 +79,54 @@ func resourceDataCenter() *schema.Resource {
 	}
 }
 
-func resourceDataCenterCreate(d *schema.ResourceData, m interface{}) error {
+func resourceDataCenterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	dataCenterAddResponse, err := client.AddDataCenter(
+		ctx,
 		d.Get("site_id").(string),
 		d.Get("name").(string),
 		d.Get("server_address").(string),
 		d.Get("is_content").(string),
+		d.Get("account_id").(int),
 	)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if d.Get("is_enabled") != "" {
 		if d.Get("is_enabled") != "" {
 			log.Printf("[INFO] Updating data center datacenter_id (%s) with is_enabled (%s)\n", dataCenterAddResponse.DataCenterID, d.Get("is_enabled").(string))
 		}
-		_, err := client.EditDataCenter(dataCenterAddResponse.DataCenterID, d.Get("name").(string), d.Get("is_content").(string), d.Get("is_enabled").(string))
+		_, err := client.EditDataCenter(ctx, dataCenterAddResponse.DataCenterID, d.Get("name").(string), d.Get("is_content").(string), d.Get("is_enabled").(string), d.Get("account_id").(int))
 		if err != nil {
 			log.Printf("[ERROR] Could not update data center datacenter_id (%s) with is_enabled (%s) %s\n", dataCenterAddResponse.DataCenterID, d.Get("is_enabled").(string), err)
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	// Set the dc ID
 	d.SetId(dataCenterAddResponse.DataCenterID)
 
-	return resourceDataCenterRead(d, m)
+	return resourceDataCenterRead(ctx, d, m)
 }
 
-func resourceDataCenterRead(d *schema.ResourceData, m interface{}) error {
+func resourceDataCenterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by reading the ListDataCentersResponse for the data center
 	client := m.(*Client)
 
-	listDataCentersResponse, err := client.ListDataCenters(d.Get("site_id").(string))
+	listDataCentersResponse, err := client.ListDataCenters(ctx, d.Get("site_id").(string), d.Get("account_id").(int))
 
-	// List data centers response object may indicate that the Site ID has been deleted (9413)
-	if listDataCentersResponse != nil {
-		// Res can oscillate between strings and ints
-		var resString string
-		if resNumber, ok := listDataCentersResponse.Res.(float64); ok {
-			resString = fmt.Sprintf("%d", int(resNumber))
-		} else {
-			resString = listDataCentersResponse.Res.(string)
-		}
-		if resString == "9413" {
-			log.Printf("[INFO] Incapsula Site ID %s has already been deleted: %s\n", d.Get("site_id"), err)
-			d.SetId("")
-			return nil
-		}
+	// Site object may have been deleted
+	if IsNotFound(err) {
+		log.Printf("[INFO] Incapsula Site ID %s has already been deleted: %s\n", d.Get("site_id"), err)
+		d.SetId("")
+		return nil
 	}
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	found := false
@@ -140,33 +148,37 @@ func resourceDataCenterRead(d *schema.ResourceData, m interface{}) error {
 		return nil
 	}
 
+	d.Set("account_id", client.accountID(d.Get("account_id").(int)))
+
 	return nil
 }
 
-func resourceDataCenterUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceDataCenterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	_, err := client.EditDataCenter(
+		ctx,
 		d.Id(),
 		d.Get("name").(string),
 		d.Get("is_content").(string),
 		d.Get("is_enabled").(string),
+		d.Get("account_id").(int),
 	)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceDataCenterDelete(d *schema.ResourceData, m interface{}) error {
+func resourceDataCenterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
-	err := client.DeleteDataCenter(d.Id())
+	err := client.DeleteDataCenter(ctx, d.Id(), d.Get("account_id").(int))
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
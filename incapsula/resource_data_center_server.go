@@ -1,25 +1,26 @@
 package incapsula
 
 import (
-	"fmt"
+	"context"
 	"log"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceDataCenterServer() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDataCenterServerCreate,
-		Read:   resourceDataCenterServerRead,
-		Update: resourceDataCenterServerUpdate,
-		Delete: resourceDataCenterServerDelete,
+		CreateContext: resourceDataCenterServerCreate,
+		ReadContext:   resourceDataCenterServerRead,
+		UpdateContext: resourceDataCenterServerUpdate,
+		DeleteContext: resourceDataCenterServerDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				idSlice := strings.Split(d.Id(), "/")
-				if len(idSlice) != 3 || idSlice[0] == "" || idSlice[1] == "" || idSlice[2] == "" {
-					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id/dc_id/server_id", d.Id())
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				idSlice, accountID, err := parseImportID(d.Id(), "site_id/dc_id/server_id")
+				if err != nil {
+					return nil, err
 				}
+				d.Set("account_id", accountID)
 
 				siteID := idSlice[0]
 				dcID := idSlice[1]
@@ -46,6 +47,13 @@ func resourceDataCenterServer() *schema.Resource {
 			},
 
 			// Optional Arguments
+			"account_id": {
+				Description: "Numeric identifier of the account to operate on. If not specified, the provider account_id is used.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
 			"server_address": {
 				Description: "The server's address.",
 				Type:        schema.TypeString,
@@ -67,58 +75,51 @@ func resourceDataCenterServer() *schema.Resource {
 	}
 }
 
-func resourceDataCenterServerCreate(d *schema.ResourceData, m interface{}) error {
+func resourceDataCenterServerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	dataCenterServerAddResponse, err := client.AddDataCenterServer(
+		ctx,
 		d.Get("dc_id").(string),
 		d.Get("server_address").(string),
 		d.Get("is_standby").(string),
+		d.Get("account_id").(int),
 	)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if d.Get("is_enabled") != "" {
 		log.Printf("[INFO] Updating data center server server_id (%s) with is_enabled (%s)\n", dataCenterServerAddResponse.ServerID, d.Get("is_enabled").(string))
-		_, err := client.EditDataCenterServer(dataCenterServerAddResponse.ServerID, d.Get("server_address").(string), d.Get("is_standby").(string), d.Get("is_enabled").(string))
+		_, err := client.EditDataCenterServer(ctx, dataCenterServerAddResponse.ServerID, d.Get("server_address").(string), d.Get("is_standby").(string), d.Get("is_enabled").(string), d.Get("account_id").(int))
 		if err != nil {
 			log.Printf("[ERROR] Could not update data center server server_id (%s) with is_enabled (%s) %s\n", dataCenterServerAddResponse.ServerID, d.Get("is_enabled").(string), err)
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	// Set the server ID
 	d.SetId(dataCenterServerAddResponse.ServerID)
 
-	return resourceDataCenterServerRead(d, m)
+	return resourceDataCenterServerRead(ctx, d, m)
 }
 
-func resourceDataCenterServerRead(d *schema.ResourceData, m interface{}) error {
+func resourceDataCenterServerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by reading the ListDataCentersResponse for the data centers
 	client := m.(*Client)
 
-	listDataCentersResponse, err := client.ListDataCenters(d.Get("site_id").(string))
+	listDataCentersResponse, err := client.ListDataCenters(ctx, d.Get("site_id").(string), d.Get("account_id").(int))
 
-	// List data centers response object may indicate that the Site ID has been deleted (9413)
-	if listDataCentersResponse != nil {
-		// Res can oscillate between strings and ints
-		var resString string
-		if resNumber, ok := listDataCentersResponse.Res.(float64); ok {
-			resString = fmt.Sprintf("%d", int(resNumber))
-		} else {
-			resString = listDataCentersResponse.Res.(string)
-		}
-		if resString == "9413" {
-			log.Printf("[INFO] Incapsula Site ID %s has already been deleted: %s\n", d.Get("site_id"), err)
-			d.SetId("")
-			return nil
-		}
+	// Site object may have been deleted
+	if IsNotFound(err) {
+		log.Printf("[INFO] Incapsula Site ID %s has already been deleted: %s\n", d.Get("site_id"), err)
+		d.SetId("")
+		return nil
 	}
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	found := false
@@ -149,33 +150,37 @@ func resourceDataCenterServerRead(d *schema.ResourceData, m interface{}) error {
 		return nil
 	}
 
+	d.Set("account_id", client.accountID(d.Get("account_id").(int)))
+
 	return nil
 }
 
-func resourceDataCenterServerUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceDataCenterServerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	_, err := client.EditDataCenterServer(
+		ctx,
 		d.Id(),
 		d.Get("server_address").(string),
 		d.Get("is_standby").(string),
 		d.Get("is_enabled").(string),
+		d.Get("account_id").(int),
 	)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceDataCenterServerDelete(d *schema.ResourceData, m interface{}) error {
+func resourceDataCenterServerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	serverID := d.Id()
-	err := client.DeleteDataCenterServer(serverID)
+	err := client.DeleteDataCenterServer(ctx, serverID, d.Get("account_id").(int))
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
@@ -1,25 +1,26 @@
 package incapsula
 
 import (
-	"fmt"
+	"context"
 	"strconv"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceIncapRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceIncapRuleCreate,
-		Read:   resourceIncapRuleRead,
-		Update: resourceIncapRuleUpdate,
-		Delete: resourceIncapRuleDelete,
+		CreateContext: resourceIncapRuleCreate,
+		ReadContext:   resourceIncapRuleRead,
+		UpdateContext: resourceIncapRuleUpdate,
+		DeleteContext: resourceIncapRuleDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				idSlice := strings.Split(d.Id(), "/")
-				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
-					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id/rule_id", d.Id())
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				idSlice, accountID, err := parseImportID(d.Id(), "site_id/rule_id")
+				if err != nil {
+					return nil, err
 				}
+				d.Set("account_id", accountID)
 
 				siteID := idSlice[0]
 				d.Set("site_id", siteID)
@@ -31,6 +32,13 @@ func resourceIncapRule() *schema.Resource {
 			},
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+
 		Schema: map[string]*schema.Schema{
 			// Required Arguments
 			"site_id": {
@@ -49,6 +57,13 @@ func resourceIncapRule() *schema.Resource {
 				Required:    true,
 			},
 			// Optional Arguments
+			"account_id": {
+				Description: "Numeric identifier of the account to operate on. If not specified, the provider account_id is used.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
 			"filter": {
 				Description: "The filter defines the conditions that trigger the rule action. For action `RULE_ACTION_SIMPLIFIED_REDIRECT` filter is not relevant. For other actions, if left empty, the rule is always run.",
 				Type:        schema.TypeString,
@@ -113,7 +128,7 @@ func resourceIncapRule() *schema.Resource {
 	}
 }
 
-func resourceIncapRuleCreate(d *schema.ResourceData, m interface{}) error {
+func resourceIncapRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	rule := IncapRule{
@@ -133,36 +148,36 @@ func resourceIncapRuleCreate(d *schema.ResourceData, m interface{}) error {
 		ErrorResponseData:   d.Get("error_response_data").(string),
 	}
 
-	ruleWithID, err := client.AddIncapRule(d.Get("site_id").(string), &rule)
+	ruleWithID, err := client.AddIncapRule(ctx, d.Get("site_id").(string), &rule, d.Get("account_id").(int))
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(strconv.Itoa(ruleWithID.RuleID))
 
-	return resourceIncapRuleRead(d, m)
+	return resourceIncapRuleRead(ctx, d, m)
 }
 
-func resourceIncapRuleRead(d *schema.ResourceData, m interface{}) error {
+func resourceIncapRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by reading the SiteResponse for the site
 	client := m.(*Client)
 
 	ruleID, err := strconv.Atoi(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	rule, statusCode, err := client.ReadIncapRule(d.Get("site_id").(string), ruleID)
+	rule, err := client.ReadIncapRule(ctx, d.Get("site_id").(string), ruleID, d.Get("account_id").(int))
 
 	// If the rule is deleted on the server, blow it out locally and run through the normal TF cycle
-	if statusCode == 404 {
+	if IsNotFound(err) {
 		d.SetId("")
 		return nil
 	}
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Update all of the properties
@@ -180,11 +195,12 @@ func resourceIncapRuleRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("error_type", rule.ErrorType)
 	d.Set("error_response_format", rule.ErrorResponseFormat)
 	d.Set("error_response_data", rule.ErrorResponseData)
+	d.Set("account_id", client.accountID(d.Get("account_id").(int)))
 
 	return nil
 }
 
-func resourceIncapRuleUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceIncapRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	rule := IncapRule{
@@ -206,29 +222,29 @@ func resourceIncapRuleUpdate(d *schema.ResourceData, m interface{}) error {
 
 	ruleID, err := strconv.Atoi(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	_, err = client.UpdateIncapRule(d.Get("site_id").(string), ruleID, &rule)
+	_, err = client.UpdateIncapRule(ctx, d.Get("site_id").(string), ruleID, &rule, d.Get("account_id").(int))
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceIncapRuleDelete(d *schema.ResourceData, m interface{}) error {
+func resourceIncapRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	ruleID, err := strconv.Atoi(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = client.DeleteIncapRule(d.Get("site_id").(string), ruleID)
+	err = client.DeleteIncapRule(ctx, d.Get("site_id").(string), ruleID, d.Get("account_id").(int))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
@@ -1,11 +1,15 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -72,13 +76,13 @@ func testAccCheckIncapsulaIncapRuleDestroy(state *terraform.State) error {
 			return fmt.Errorf("Incapsula Site ID does not exist for Rule ID %d", ruleID)
 		}
 
-		_, statusCode, err := client.ReadIncapRule(siteID, ruleID)
-		if statusCode != 404 {
-			return fmt.Errorf("Incapsula Incap Rule %d (site id: %s) should have received 404 status code", ruleID, siteID)
-		}
+		_, err = client.ReadIncapRule(context.Background(), siteID, ruleID, 0)
 		if err == nil {
 			return fmt.Errorf("Incapsula Incap Rule %d still exists for Site ID %s", ruleID, siteID)
 		}
+		if !IsNotFound(err) {
+			return fmt.Errorf("Incapsula Incap Rule %d (site id: %s) should have received a not found error, got: %s", ruleID, siteID, err)
+		}
 	}
 
 	return nil
@@ -102,10 +106,7 @@ func testCheckIncapsulaIncapRuleExists(name string) resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		_, statusCode, err := client.ReadIncapRule(siteID, ruleID)
-		if statusCode != 200 {
-			return fmt.Errorf("Incapsula Incap Rule: %s (site id: %s) should have received 200 status code", name, siteID)
-		}
+		_, err = client.ReadIncapRule(context.Background(), siteID, ruleID, 0)
 		if err != nil {
 			return fmt.Errorf("Incapsula Incap Rule: %s (site id: %s) does not exist", name, siteID)
 		}
@@ -125,3 +126,37 @@ resource "incapsula_incap_rule" "testacc-terraform-incap-rule" {
 }`, incapRuleName, siteResourceName,
 	)
 }
+
+func TestResourceIncapRuleImportSubAccount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/sites/1234/rules/5678" {
+			t.Errorf("Should have have hit /sites/1234/rules/5678 endpoint. Got: %s", req.URL.Path)
+		}
+		if caid := req.URL.Query().Get("caid"); caid != "42" {
+			t.Errorf("Should have sent the imported account_id as caid, got: %s", caid)
+		}
+		rw.Write([]byte(`{"id":5678,"name":"Example rule","action":"RULE_ACTION_ALERT","filter":"Full-URL == /someurl"}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", AccountID: 1, BaseURLRev2: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	d := schema.TestResourceDataRaw(t, resourceIncapRule().Schema, map[string]interface{}{})
+	d.SetId("1234/5678/42")
+
+	results, err := resourceIncapRule().Importer.StateContext(context.Background(), d, client)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if len(results) != 1 || d.Id() != "5678" || d.Get("site_id").(string) != "1234" || d.Get("account_id").(int) != 42 {
+		t.Fatalf("Should have imported rule 5678 of site 1234 and account 42, got ID %s, site_id %s and account_id %d", d.Id(), d.Get("site_id"), d.Get("account_id"))
+	}
+
+	if diags := resourceIncapRuleRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("Should not have received an error, got: %+v", diags)
+	}
+	if d.Get("account_id").(int) != 42 || d.Get("name").(string) != "Example rule" {
+		t.Errorf("Should have kept the imported account_id and read the rule, got account_id %d and name %s", d.Get("account_id"), d.Get("name"))
+	}
+}
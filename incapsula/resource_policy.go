@@ -1,22 +1,31 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourcePolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePolicyCreate,
-		Read:   resourcePolicyRead,
-		Update: resourcePolicyUpdate,
-		Delete: resourcePolicyDelete,
+		CreateContext: resourcePolicyCreate,
+		ReadContext:   resourcePolicyRead,
+		UpdateContext: resourcePolicyUpdate,
+		DeleteContext: resourcePolicyDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -68,7 +77,7 @@ func resourcePolicy() *schema.Resource {
 	}
 }
 
-func resourcePolicyCreate(d *schema.ResourceData, m interface{}) error {
+func resourcePolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	policySettingsString := d.Get("policy_settings").(string)
@@ -84,11 +93,11 @@ func resourcePolicyCreate(d *schema.ResourceData, m interface{}) error {
 		PolicySettings: policySettings,
 	}
 
-	policyAddResponse, err := client.AddPolicy(&policySubmitted)
+	policyAddResponse, err := client.AddPolicy(ctx, &policySubmitted)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not create Incapsula policy: %s - %s\n", policySubmitted.Name, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the policyID
@@ -96,18 +105,25 @@ func resourcePolicyCreate(d *schema.ResourceData, m interface{}) error {
 	d.SetId(policyID)
 	log.Printf("[INFO] Created Incapsula policy with ID: %s\n", policyID)
 
-	return resourcePolicyRead(d, m)
+	return resourcePolicyRead(ctx, d, m)
 }
 
-func resourcePolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourcePolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	policyID := d.Id()
-	policyGetResponse, err := client.GetPolicy(policyID)
+	policyGetResponse, err := client.GetPolicy(ctx, policyID, d.Get("account_id").(int))
+
+	// Policy object may have been deleted
+	if IsNotFound(err) {
+		log.Printf("[INFO] Incapsula Policy ID %s has already been deleted: %s\n", policyID, err)
+		d.SetId("")
+		return nil
+	}
 
 	if err != nil {
 		log.Printf("[ERROR] Could not get Incapsula policy: %s - %s\n", policyID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set computed values
@@ -121,19 +137,19 @@ func resourcePolicyRead(d *schema.ResourceData, m interface{}) error {
 	policySettingsJSONBytes, err := json.MarshalIndent(policyGetResponse.Value.PolicySettings, "", "    ")
 	if err != nil {
 		log.Printf("[ERROR] Could not get marshal Incapsula policy settings: %s - %s - %s\n", policyID, err, policySettingsJSONBytes)
-		return err
+		return diag.FromErr(err)
 	}
 	d.Set("policy_settings", string(policySettingsJSONBytes))
 
 	return nil
 }
 
-func resourcePolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	policySettingsString := d.Get("policy_settings").(string)
@@ -149,22 +165,22 @@ func resourcePolicyUpdate(d *schema.ResourceData, m interface{}) error {
 		PolicySettings: policySettings,
 	}
 
-	_, err = client.UpdatePolicy(id, &policySubmitted)
+	_, err = client.UpdatePolicy(ctx, id, &policySubmitted, d.Get("account_id").(int))
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourcePolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
-	err := client.DeletePolicy(d.Id())
+	err := client.DeletePolicy(ctx, d.Id(), d.Get("account_id").(int))
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
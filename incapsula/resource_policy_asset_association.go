@@ -1,19 +1,21 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourcePolicyAssetAssociation() *schema.Resource {
 	return &schema.Resource{
-		Create:   resourcePolicyAssetAssociationCreate,
-		Read:     resourcePolicyAssetAssociationNil,
-		Update:   resourcePolicyAssetAssociationUpdate,
-		Delete:   resourcePolicyAssetAssociationDelete,
-		Importer: nil,
+		CreateContext: resourcePolicyAssetAssociationCreate,
+		ReadContext:   resourcePolicyAssetAssociationNil,
+		UpdateContext: resourcePolicyAssetAssociationUpdate,
+		DeleteContext: resourcePolicyAssetAssociationDelete,
+		Importer:      nil,
 
 		Schema: map[string]*schema.Schema{
 			// Required Arguments
@@ -32,37 +34,46 @@ func resourcePolicyAssetAssociation() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 			},
+			// Optional Arguments
+			"account_id": {
+				Description: "Numeric identifier of the account to operate on. If not specified, the provider account_id is used.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
 		},
 	}
 }
 
-func resourcePolicyAssetAssociationCreate(d *schema.ResourceData, m interface{}) error {
+func resourcePolicyAssetAssociationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	policyID := d.Get("policy_id").(string)
 	assetID := d.Get("asset_id").(string)
 	assetType := d.Get("asset_type").(string)
 
-	err := client.AddPolicyAssetAssociation(policyID, assetID, assetType)
+	err := client.AddPolicyAssetAssociation(ctx, policyID, assetID, assetType, d.Get("account_id").(int))
 
 	if err != nil {
 		log.Printf("[ERROR] Could not create Incapsula policy asset association: policy ID (%s) - asset ID (%s) - asset type (%s) - %s\n", policyID, assetID, assetType, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Generate synthetic ID
 	syntheticID := fmt.Sprintf("%s-%s-%s", policyID, assetID, assetType)
 	d.SetId(syntheticID)
+	d.Set("account_id", client.accountID(d.Get("account_id").(int)))
 	log.Printf("[INFO] Created Incapsula policy asset association with ID: %s - policy ID (%s) - asset ID (%s) - asset type (%s)\n", syntheticID, policyID, assetID, assetType)
 
 	return nil
 }
 
-func resourcePolicyAssetAssociationNil(d *schema.ResourceData, m interface{}) error {
+func resourcePolicyAssetAssociationNil(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return nil
 }
 
-func resourcePolicyAssetAssociationUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePolicyAssetAssociationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// We can end up in a situation where a user can change a attribute after creation
 	// Since policy asset association stacks the associations, we'll need to remove the prior before creating the new one
 	client := m.(*Client)
@@ -72,16 +83,16 @@ func resourcePolicyAssetAssociationUpdate(d *schema.ResourceData, m interface{})
 	oldAssetType, newAssetType := d.GetChange("asset_type")
 
 	// Delete the old
-	err := client.DeletePolicyAssetAssociation(oldPolicyID.(string), oldAssetID.(string), oldAssetType.(string))
+	err := client.DeletePolicyAssetAssociation(ctx, oldPolicyID.(string), oldAssetID.(string), oldAssetType.(string), d.Get("account_id").(int))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Add the new
-	err = client.AddPolicyAssetAssociation(newPolicyID.(string), newAssetID.(string), newAssetType.(string))
+	err = client.AddPolicyAssetAssociation(ctx, newPolicyID.(string), newAssetID.(string), newAssetType.(string), d.Get("account_id").(int))
 	if err != nil {
 		log.Printf("[ERROR] Could not create Incapsula policy asset association: policy ID (%s) - asset ID (%s) - asset type (%s) - %s\n", newPolicyID.(string), newAssetID.(string), newAssetType.(string), err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Re-generate synthetic ID
@@ -92,17 +103,17 @@ func resourcePolicyAssetAssociationUpdate(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourcePolicyAssetAssociationDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePolicyAssetAssociationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	policyID := d.Get("policy_id").(string)
 	assetID := d.Get("asset_id").(string)
 	assetType := d.Get("asset_type").(string)
 
-	err := client.DeletePolicyAssetAssociation(policyID, assetID, assetType)
+	err := client.DeletePolicyAssetAssociation(ctx, policyID, assetID, assetType, d.Get("account_id").(int))
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
@@ -1,11 +1,11 @@
 package incapsula
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"strconv"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -24,21 +24,22 @@ const botAccessControlExceptionRuleID = "api.threats.bot_access_control"
 
 // DeleteSecurityRuleExceptionResponse contains the response code for deleting a security exception
 type DeleteSecurityRuleExceptionResponse struct {
-	Res int `json:"res"`
+	Res ResCode `json:"res"`
 }
 
 func resourceSecurityRuleException() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSecurityRuleExceptionCreate,
-		Read:   resourceSecurityRuleExceptionRead,
-		Update: resourceSecurityRuleExceptionUpdate,
-		Delete: resourceSecurityRuleExceptionDelete,
+		CreateContext: resourceSecurityRuleExceptionCreate,
+		ReadContext:   resourceSecurityRuleExceptionRead,
+		UpdateContext: resourceSecurityRuleExceptionUpdate,
+		DeleteContext: resourceSecurityRuleExceptionDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				idSlice := strings.Split(d.Id(), "/")
-				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
-					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id/rule_id", d.Id())
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				idSlice, accountID, err := parseImportID(d.Id(), "site_id/rule_id")
+				if err != nil {
+					return nil, err
 				}
+				d.Set("account_id", accountID)
 
 				siteID, err := strconv.Atoi(idSlice[0])
 				ruleID := idSlice[1]
@@ -52,6 +53,13 @@ func resourceSecurityRuleException() *schema.Resource {
 			},
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+
 		Schema: map[string]*schema.Schema{
 			// Required Arguments
 			"site_id": {
@@ -64,6 +72,14 @@ func resourceSecurityRuleException() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 			},
+			// Optional Arguments
+			"account_id": {
+				Description: "Numeric identifier of the account to operate on. If not specified, the provider account_id is used.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
 			"client_app_types": {
 				Description:      "A comma separated list of client application types,",
 				Type:             schema.TypeString,
@@ -132,13 +148,14 @@ func resourceSecurityRuleException() *schema.Resource {
 	}
 }
 
-func resourceSecurityRuleExceptionCreate(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityRuleExceptionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	ruleID := d.Get("rule_id").(string)
 
 	log.Printf("[INFO] Configuring Incapsula Security Rule Exception for rule_id (%s) on site_id (%d)\n", ruleID, d.Get("site_id").(int))
 	siteStatusResponse, err := client.AddSecurityRuleException(
+		ctx,
 		d.Get("site_id").(int),
 		ruleID,
 		d.Get("client_app_types").(string),
@@ -150,10 +167,11 @@ func resourceSecurityRuleExceptionCreate(d *schema.ResourceData, m interface{})
 		d.Get("urls").(string),
 		d.Get("user_agents").(string),
 		d.Get("parameters").(string),
+		d.Get("account_id").(int),
 	)
 	if err != nil {
 		log.Printf("[ERROR] Could not create Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the rule exception ID
@@ -161,10 +179,10 @@ func resourceSecurityRuleExceptionCreate(d *schema.ResourceData, m interface{})
 
 	log.Printf("[INFO] Created Incapsula security rule exception for rule_id (%s) on site_id (%d)\n", ruleID, d.Get("site_id").(int))
 
-	return resourceSecurityRuleExceptionRead(d, m)
+	return resourceSecurityRuleExceptionRead(ctx, d, m)
 }
 
-func resourceSecurityRuleExceptionRead(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityRuleExceptionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by reading the SiteResponse for the site
 	client := m.(*Client)
 
@@ -174,10 +192,10 @@ func resourceSecurityRuleExceptionRead(d *schema.ResourceData, m interface{}) er
 
 	log.Printf("[INFO] Reading Incapsula security rule exception whitelist_id (%d) on rule_id (%s) \n", whitelistID, ruleID)
 
-	siteStatusResponse, err := client.ListSecurityRuleExceptions(siteID, ruleID)
+	siteStatusResponse, err := client.ListSecurityRuleExceptions(ctx, siteID, ruleID, d.Get("account_id").(int))
 
 	// Site object may have been deleted
-	if siteStatusResponse != nil && siteStatusResponse.Res.(float64) == 9413 {
+	if IsNotFound(err) {
 		log.Printf("[INFO] Incapsula Site ID %s has already been deleted: %s\n", d.Get("site_id"), err)
 		d.SetId("")
 		return nil
@@ -185,7 +203,7 @@ func resourceSecurityRuleExceptionRead(d *schema.ResourceData, m interface{}) er
 
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula security rule exception whitelist_id (%d) on rule_id (%s) %s\n", whitelistID, ruleID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Now with the site status, iterate through the rules and find our ID
@@ -223,13 +241,14 @@ func resourceSecurityRuleExceptionRead(d *schema.ResourceData, m interface{}) er
 		log.Printf("[ERROR] Read Incapsula security rule exception failed, exception not found: whitelist_id (%d) and rule_id (%s) on site_id (%d)\n", whitelistID, ruleID, d.Get("site_id").(int))
 		d.SetId("")
 	} else {
+		d.Set("account_id", client.accountID(d.Get("account_id").(int)))
 		log.Printf("[INFO] Read Incapsula security rule exception whitelist_id (%d) and rule_id (%s) on site_id (%d)\n", whitelistID, ruleID, d.Get("site_id").(int))
 	}
 
 	return nil
 }
 
-func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityRuleExceptionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	ruleID := d.Get("rule_id").(string)
@@ -242,6 +261,7 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 	// ACL RuleIDs
 	case blacklistedCountriesExceptionRuleID:
 		_, err := client.EditSecurityRuleException(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			d.Get("client_app_types").(string),
@@ -254,13 +274,15 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 			"",
 			"",
 			whitelistID,
+			d.Get("account_id").(int),
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case blacklistedIPsExceptionRuleID:
 		_, err := client.EditSecurityRuleException(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -273,13 +295,15 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 			"",
 			"",
 			whitelistID,
+			d.Get("account_id").(int),
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case blacklistedURLsExceptionRuleID:
 		_, err := client.EditSecurityRuleException(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -292,13 +316,15 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 			"",
 			"",
 			whitelistID,
+			d.Get("account_id").(int),
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case backdoorExceptionRuleID:
 		_, err := client.EditSecurityRuleException(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -311,13 +337,15 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 			d.Get("user_agents").(string),
 			d.Get("parameters").(string),
 			whitelistID,
+			d.Get("account_id").(int),
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case botAccessControlExceptionRuleID:
 		_, err := client.EditSecurityRuleException(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -330,13 +358,15 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 			d.Get("user_agents").(string),
 			"",
 			whitelistID,
+			d.Get("account_id").(int),
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case crossSiteScriptingExceptionRuleID:
 		_, err := client.EditSecurityRuleException(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -349,13 +379,15 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 			"",
 			d.Get("parameters").(string),
 			whitelistID,
+			d.Get("account_id").(int),
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case ddosExceptionRuleID:
 		_, err := client.EditSecurityRuleException(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -368,13 +400,15 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 			"",
 			"",
 			whitelistID,
+			d.Get("account_id").(int),
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case illegalResourceAccessExceptionRuleID:
 		_, err := client.EditSecurityRuleException(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -387,13 +421,15 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 			"",
 			d.Get("parameters").(string),
 			whitelistID,
+			d.Get("account_id").(int),
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case remoteFileInclusionExceptionRuleID:
 		_, err := client.EditSecurityRuleException(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -406,13 +442,15 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 			d.Get("user_agents").(string),
 			d.Get("parameters").(string),
 			whitelistID,
+			d.Get("account_id").(int),
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case sqlInjectionExceptionRuleID:
 		_, err := client.EditSecurityRuleException(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -425,10 +463,11 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 			"",
 			"",
 			whitelistID,
+			d.Get("account_id").(int),
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -437,10 +476,10 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 
 	log.Printf("[INFO] Updated Incapsula security rule exception for rule_id (%s) on site_id (%d)\n", ruleID, d.Get("site_id").(int))
 
-	return resourceWAFSecurityRuleRead(d, m)
+	return resourceWAFSecurityRuleRead(ctx, d, m)
 }
 
-func resourceSecurityRuleExceptionDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityRuleExceptionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	ruleID := d.Get("rule_id").(string)
@@ -449,13 +488,15 @@ func resourceSecurityRuleExceptionDelete(d *schema.ResourceData, m interface{})
 	log.Printf("[INFO] Deleting Incapsula security rule exception whitelist_id (%s) for rule_id (%s) on site_id (%d)\n", whitelistID, ruleID, d.Get("site_id").(int))
 
 	err := client.DeleteSecurityRuleException(
+		ctx,
 		d.Get("site_id").(int),
 		ruleID,
 		whitelistID,
+		d.Get("account_id").(int),
 	)
 	if err != nil {
 		log.Printf("[ERROR] Could not delete Incapsula security rule exception whitelist_id (%s) for rule_id (%s) on site_id (%d), %s\n", whitelistID, ruleID, d.Get("site_id").(int), err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
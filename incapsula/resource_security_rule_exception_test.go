@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"testing"
@@ -123,7 +124,7 @@ func testCheckSecurityRuleExceptionExists(name string) resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		siteStatusResponse, err := client.ListSecurityRuleExceptions(siteID, ruleID)
+		siteStatusResponse, err := client.ListSecurityRuleExceptions(context.Background(), siteID, ruleID, 0)
 		if err != nil {
 			return fmt.Errorf("ListSecurityRuleExceptions Error for site_id (%s) and rule_id (%s) %s", siteID, ruleID, err)
 		}
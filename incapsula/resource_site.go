@@ -1,23 +1,33 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceSite() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSiteCreate,
-		Read:   resourceSiteRead,
-		Update: resourceSiteUpdate,
-		Delete: resourceSiteDelete,
+		CreateContext: resourceSiteCreate,
+		ReadContext:   resourceSiteRead,
+		UpdateContext: resourceSiteUpdate,
+		DeleteContext: resourceSiteDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -142,90 +152,105 @@ func resourceSite() *schema.Resource {
 			},
 			"perf_client_comply_no_cache": {
 				Description: "Comply with No-Cache and Max-Age directives in client requests. By default, these cache directives are ignored. Resources are dynamically profiled and re-configured to optimize performance.",
+				Deprecated:  "Use the incapsula_site_cache_settings resource instead.",
 				Type:        schema.TypeBool,
 				Computed:    true,
 				Optional:    true,
 			},
 			"perf_client_enable_client_side_caching": {
 				Description: "Cache content on client browsers or applications. When not enabled, content is cached only on the Imperva proxies.",
+				Deprecated:  "Use the incapsula_site_cache_settings resource instead.",
 				Type:        schema.TypeBool,
 				Computed:    true,
 				Optional:    true,
 			},
 			"perf_client_send_age_header": {
 				Description: "Send Cache-Control: max-age and Age headers.",
+				Deprecated:  "Use the incapsula_site_cache_settings resource instead.",
 				Type:        schema.TypeBool,
 				Computed:    true,
 				Optional:    true,
 			},
 			"perf_key_comply_vary": {
 				Description: "Comply with Vary. Cache resources in accordance with the Vary response header.",
+				Deprecated:  "Use the incapsula_site_cache_settings resource instead.",
 				Type:        schema.TypeBool,
 				Computed:    true,
 				Optional:    true,
 			},
 			"perf_key_unite_naked_full_cache": {
 				Description: "Use the Same Cache for Full and Naked Domains. For example, use the same cached resource for www.example.com/a and example.com/a.",
+				Deprecated:  "Use the incapsula_site_cache_settings resource instead.",
 				Type:        schema.TypeBool,
 				Computed:    true,
 				Optional:    true,
 			},
 			"perf_mode_https": {
 				Description: "The resources that are cached over HTTPS, the general level applies. Options are `disabled`, `dont_include_html`, `include_html`, and `include_all_resources`.",
+				Deprecated:  "Use the incapsula_site_cache_settings resource instead.",
 				Type:        schema.TypeString,
 				Computed:    true,
 				Optional:    true,
 			},
 			"perf_mode_level": {
 				Description: "Caching level. Options are `disable`, `standard`, `smart`, and `all_resources`.",
+				Deprecated:  "Use the incapsula_site_cache_settings resource instead.",
 				Type:        schema.TypeString,
 				Computed:    true,
 				Optional:    true,
 			},
 			"perf_mode_time": {
 				Description: "The time, in seconds, that you set for this option determines how often the cache is refreshed. Relevant for the `include_html` and `include_all_resources` levels only.",
+				Deprecated:  "Use the incapsula_site_cache_settings resource instead.",
 				Type:        schema.TypeInt,
 				Computed:    true,
 				Optional:    true,
 			},
 			"perf_response_cache_300x": {
 				Description: "When this option is checked Imperva will cache 301, 302, 303, 307, and 308 redirect response headers containing the target URI.",
+				Deprecated:  "Use the incapsula_site_cache_settings resource instead.",
 				Type:        schema.TypeBool,
 				Computed:    true,
 				Optional:    true,
 			},
 			"perf_response_cache_404_enabled": {
 				Description: "Whether or not to cache 404 responses.",
+				Deprecated:  "Use the incapsula_site_cache_settings resource instead.",
 				Type:        schema.TypeBool,
 				Computed:    true,
 				Optional:    true,
 			},
 			"perf_response_cache_404_time": {
 				Description: "The time in seconds to cache 404 responses.",
+				Deprecated:  "Use the incapsula_site_cache_settings resource instead.",
 				Type:        schema.TypeInt,
 				Computed:    true,
 				Optional:    true,
 			},
 			"perf_response_cache_empty_responses": {
 				Description: "Cache responses that don’t have a message body.",
+				Deprecated:  "Use the incapsula_site_cache_settings resource instead.",
 				Type:        schema.TypeBool,
 				Computed:    true,
 				Optional:    true,
 			},
 			"perf_response_cache_http_10_responses": {
 				Description: "Cache HTTP 1.0 type responses that don’t include the Content-Length header or chunking.",
+				Deprecated:  "Use the incapsula_site_cache_settings resource instead.",
 				Type:        schema.TypeBool,
 				Computed:    true,
 				Optional:    true,
 			},
 			"perf_response_cache_response_header_mode": {
 				Description: "The working mode for caching response headers. Options are `all` and `custom`.",
+				Deprecated:  "Use the incapsula_site_cache_settings resource instead.",
 				Type:        schema.TypeString,
 				Computed:    true,
 				Optional:    true,
 			},
 			"perf_response_cache_response_headers": {
 				Description: "An array of strings representing the response headers to be cached when working in `custom` mode. If empty, no response headers are cached.",
+				Deprecated:  "Use the incapsula_site_cache_settings resource instead.",
 				Type:        schema.TypeList,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
@@ -236,41 +261,72 @@ func resourceSite() *schema.Resource {
 			},
 			"perf_response_cache_shield": {
 				Description: "Adds an intermediate cache between other Imperva PoPs and your origin servers to protect your servers from redundant requests.",
+				Deprecated:  "Use the incapsula_site_cache_settings resource instead.",
 				Type:        schema.TypeBool,
 				Computed:    true,
 				Optional:    true,
 			},
 			"perf_response_stale_content_mode": {
 				Description: "The working mode for serving stale content. Options are `disabled`, `adaptive`, and `custom`.",
+				Deprecated:  "Use the incapsula_site_cache_settings resource instead.",
 				Type:        schema.TypeString,
 				Computed:    true,
 				Optional:    true,
 			},
 			"perf_response_stale_content_time": {
 				Description: "The time, in seconds, to serve stale content for when working in `custom` work mode.",
+				Deprecated:  "Use the incapsula_site_cache_settings resource instead.",
 				Type:        schema.TypeInt,
 				Computed:    true,
 				Optional:    true,
 			},
 			"perf_response_tag_response_header": {
 				Description: "Tag the response according to the value of this header. Specify which origin response header contains the cache tags in your resources.",
+				Deprecated:  "Use the incapsula_site_cache_settings resource instead.",
 				Type:        schema.TypeString,
 				Computed:    true,
 				Optional:    true,
 			},
 			"perf_ttl_prefer_last_modified": {
 				Description: "Prefer 'Last Modified' over eTag. When this option is checked, Imperva prefers using Last Modified values (if available) over eTag values (recommended on multi-server setups).",
+				Deprecated:  "Use the incapsula_site_cache_settings resource instead.",
 				Type:        schema.TypeBool,
 				Computed:    true,
 				Optional:    true,
 			},
 			"perf_ttl_use_shortest_caching": {
 				Description: "Use shortest caching duration in case of conflicts. By default, the longest duration is used in case of conflict between caching rules or modes. When this option is checked, Imperva uses the shortest duration in case of conflict.",
+				Deprecated:  "Use the incapsula_site_cache_settings resource instead.",
 				Type:        schema.TypeBool,
 				Computed:    true,
 				Optional:    true,
 			},
 
+			"wait_for_ssl_validation": {
+				Description: "Wait for the generated certificate to be validated when creating or updating the site.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"wait_for_status": {
+				Description: "Wait for the site to reach this status (e.g. fully_configured) when creating or updating the site.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+
+			"deletion_protection": {
+				Description: "Prevent the site from being deleted (or bypassed, see bypass_on_destroy) by Terraform.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"bypass_on_destroy": {
+				Description: "Set the site to bypass mode and remove it from the Terraform state instead of deleting it on destroy.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
 			// Computed Attributes
 			"site_creation_date": {
 				Description: "Numeric representation of the site creation date.",
@@ -300,22 +356,61 @@ func resourceSite() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"dns_records":          siteDNSRecordsSchema("The DNS records to set to route the site traffic through Incapsula."),
+			"original_dns_records": siteDNSRecordsSchema("The DNS records of the site before it was onboarded."),
 			"domain_verification": {
 				Description: "Domain verification (e.g. GlobalSign verification).",
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"ssl_validation_records": {
+				Description: "The records to publish to validate the generated certificate (e.g. GlobalSign verification).",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "The record name (DNS record name or domain).",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type": {
+							Description: "The record type, e.g. TXT or CNAME for dns, meta for html or email for email.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"values": {
+							Description: "The record values (DNS record values, meta tags or email addresses).",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"method": {
+							Description: "The validation method (dns, html or email).",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"ssl_san": {
+				Description: "The SANs of the generated certificate.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
 
-func resourceSiteCreate(d *schema.ResourceData, m interface{}) error {
+func resourceSiteCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	domain := d.Get("domain").(string)
 
 	log.Printf("[INFO] Creating Incapsula site for domain: %s\n", domain)
 
 	siteAddResponse, err := client.AddSite(
+		ctx,
 		domain,
 		d.Get("ref_id").(string),
 		d.Get("send_site_setup_emails").(string),
@@ -326,47 +421,54 @@ func resourceSiteCreate(d *schema.ResourceData, m interface{}) error {
 
 	if err != nil {
 		log.Printf("[ERROR] Could not create Incapsula site for domain: %s, %s\n", domain, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the Site ID
 	d.SetId(strconv.Itoa(siteAddResponse.SiteID))
 	log.Printf("[INFO] Created Incapsula site for domain: %s\n", domain)
 
-	// There may be a timing/race condition here
-	// Set an arbitrary period to sleep
-	time.Sleep(3 * time.Second)
+	// The site can't be configured until the API reports its status
+	err = waitForSiteReady(ctx, client, domain, siteAddResponse.SiteID, d.Get("account_id").(int), d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	err = updateAdditionalSiteProperties(client, d)
+	err = updateAdditionalSiteProperties(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = updateDataStorageRegion(client, d)
+	err = updateDataStorageRegion(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = updateMaskingSettings(client, d)
+	err = updateMaskingSettings(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = updateLogLevel(client, d)
+	err = updateLogLevel(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = updatePerformanceSettings(client, d)
+	err = updatePerformanceSettings(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+
+	err = waitForSiteSettings(ctx, client, d, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	// Set the rest of the state from the resource read
-	return resourceSiteRead(d, m)
+	return resourceSiteRead(ctx, d, m)
 }
 
-func resourceSiteRead(d *schema.ResourceData, m interface{}) error {
+func resourceSiteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	domain := d.Get("domain").(string)
@@ -374,10 +476,10 @@ func resourceSiteRead(d *schema.ResourceData, m interface{}) error {
 
 	log.Printf("[INFO] Reading Incapsula site for domain: %s\n", domain)
 
-	siteStatusResponse, err := client.SiteStatus(domain, siteID)
+	siteStatusResponse, err := client.SiteStatus(ctx, domain, siteID, d.Get("account_id").(int))
 
 	// Site object may have been deleted
-	if siteStatusResponse != nil && siteStatusResponse.Res.(float64) == 9413 {
+	if IsNotFound(err) {
 		log.Printf("[INFO] Incapsula Site ID %d has already been deleted: %s\n", siteID, err)
 		d.SetId("")
 		return nil
@@ -385,7 +487,7 @@ func resourceSiteRead(d *schema.ResourceData, m interface{}) error {
 
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula site for domain: %s, %s\n", domain, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("site_creation_date", siteStatusResponse.SiteCreationDate)
@@ -405,24 +507,42 @@ func resourceSiteRead(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 	d.Set("dns_a_record_value", dnsARecordValues)
+	if err := d.Set("dns_records", flattenSiteDNSRecords(siteStatusResponse.DNS)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("original_dns_records", flattenSiteDNSRecords(siteStatusResponse.OriginalDNS)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Set the generated certificate validation records (e.g. GlobalSign verification)
+	generatedCertificate := siteStatusResponse.Ssl.GeneratedCertificate
+	validationRecords := make([]SiteValidationRecord, 0)
+	if generatedCertificate.ValidationMethod != "" {
+		validationRecords, err = parseSiteValidationRecords(generatedCertificate.ValidationMethod, generatedCertificate.ValidationData)
+		if err != nil {
+			log.Printf("[WARN] Could not parse the SSL validation data of Incapsula site for domain: %s, %s\n", domain, err)
+			validationRecords = make([]SiteValidationRecord, 0)
+		}
+	}
 
-	// Set the GlobalSign verification
-	if siteStatusResponse.Ssl.GeneratedCertificate.ValidationMethod == "dns" {
-		dnsValidation := siteStatusResponse.Ssl.GeneratedCertificate.ValidationData.([]interface{})
-		dnsRecord := dnsValidation[0].(map[string]interface{})
-		setDataTo := dnsRecord["set_data_to"].([]interface{})[0]
-		d.Set("domain_verification", setDataTo)
-	}
-
-	// Set the HTML verification
-	if siteStatusResponse.Ssl.GeneratedCertificate.ValidationMethod == "html" {
-		htmlValidation := siteStatusResponse.Ssl.GeneratedCertificate.ValidationData.(map[string]interface{})
-		for _, value := range htmlValidation {
-			metaTag := value.([]interface{})[0]
-			d.Set("domain_verification", metaTag)
-			break
+	domainVerification := ""
+	flattenedValidationRecords := make([]interface{}, 0, len(validationRecords))
+	for _, record := range validationRecords {
+		if domainVerification == "" && len(record.Values) > 0 {
+			domainVerification = record.Values[0]
 		}
+		flattenedValidationRecords = append(flattenedValidationRecords, map[string]interface{}{
+			"name":   record.Name,
+			"type":   record.Type,
+			"values": record.Values,
+			"method": record.Method,
+		})
 	}
+	d.Set("domain_verification", domainVerification)
+	if err := d.Set("ssl_validation_records", flattenedValidationRecords); err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("ssl_san", generatedCertificate.San)
 
 	// Get the log level for the site
 	if siteStatusResponse.LogLevel != "" {
@@ -430,27 +550,27 @@ func resourceSiteRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	// Get the data storage region for the site
-	dataStorageRegionResponse, err := client.GetDataStorageRegion(d.Id())
+	dataStorageRegionResponse, err := client.GetDataStorageRegion(ctx, d.Id(), d.Get("account_id").(int))
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula site data storage region for domain: %s and site id: %d, %s\n", domain, siteID, err)
-		return err
+		return diag.FromErr(err)
 	}
 	d.Set("data_storage_region", dataStorageRegionResponse.Region)
 
 	// Get the masking settings for the site
-	maskingResponse, err := client.GetMaskingSettings(d.Id())
+	maskingResponse, err := client.GetMaskingSettings(ctx, d.Id(), d.Get("account_id").(int))
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula site masking settings for domain: %s and site id: %d, %s\n", domain, siteID, err)
-		return err
+		return diag.FromErr(err)
 	}
 	d.Set("hashing_enabled", maskingResponse.HashingEnabled)
 	d.Set("hash_salt", maskingResponse.HashSalt)
 
 	// Get the performance settings for the site
-	performanceSettingsResponse, _, err := client.GetPerformanceSettings(d.Id())
+	performanceSettingsResponse, err := client.GetPerformanceSettings(ctx, d.Id(), d.Get("account_id").(int))
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula site peformance settings for domain: %s and site id: %d, %s\n", domain, siteID, err)
-		return err
+		return diag.FromErr(err)
 	}
 	d.Set("perf_client_comply_no_cache", performanceSettingsResponse.ClientSide.ComplyNoCache)
 	d.Set("perf_client_enable_client_side_caching", performanceSettingsResponse.ClientSide.EnableClientSideCaching)
@@ -479,50 +599,87 @@ func resourceSiteRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSiteUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSiteUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
-	err := updateAdditionalSiteProperties(client, d)
+	err := updateAdditionalSiteProperties(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = updateDataStorageRegion(client, d)
+	err = updateDataStorageRegion(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = updateMaskingSettings(client, d)
+	err = updateMaskingSettings(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = updateLogLevel(client, d)
+	err = updateLogLevel(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = updatePerformanceSettings(client, d)
+	err = updatePerformanceSettings(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+
+	// Only wait again when the update may have changed the SSL validation or the status of the site
+	if d.HasChanges(siteWaitArguments...) {
+		err = waitForSiteSettings(ctx, client, d, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	// Set the rest of the state from the resource read
-	return resourceSiteRead(d, m)
+	return resourceSiteRead(ctx, d, m)
 }
 
-func resourceSiteDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSiteDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	domain := d.Get("domain").(string)
 	siteID, _ := strconv.Atoi(d.Id())
 
+	if d.Get("deletion_protection").(bool) {
+		log.Printf("[ERROR] Could not delete Incapsula site for domain: %s, deletion protection is enabled\n", domain)
+		return diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Cannot delete Incapsula site %s (%d): deletion_protection is enabled", domain, siteID),
+				Detail:   "Set deletion_protection to false and apply the change before deleting (or replacing) the site.",
+			},
+		}
+	}
+
+	// Keep the site (and its rules) in bypass mode and only remove it from the state
+	if d.Get("bypass_on_destroy").(bool) {
+		log.Printf("[INFO] Bypassing Incapsula site for domain: %s instead of deleting it\n", domain)
+
+		_, err := client.UpdateSite(ctx, d.Id(), "active", "bypass", d.Get("account_id").(int))
+
+		if err != nil && !IsNotFound(err) {
+			log.Printf("[ERROR] Could not bypass Incapsula site for domain: %s, %s\n", domain, err)
+			return diag.FromErr(err)
+		}
+
+		d.SetId("")
+
+		log.Printf("[INFO] Bypassed Incapsula site for domain: %s and removed it from the state\n", domain)
+
+		return nil
+	}
+
 	log.Printf("[INFO] Deleting Incapsula site for domain: %s\n", domain)
 
-	err := client.DeleteSite(domain, siteID)
+	err := client.DeleteSite(ctx, domain, siteID, d.Get("account_id").(int))
 
 	if err != nil {
 		log.Printf("[ERROR] Could not delete Incapsula site for domain: %s, %s\n", domain, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
@@ -534,13 +691,13 @@ func resourceSiteDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func updateAdditionalSiteProperties(client *Client, d *schema.ResourceData) error {
+func updateAdditionalSiteProperties(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	updateParams := [7]string{"acceleration_level", "active", "approver", "domain_redirect_to_full", "domain_validation", "ignore_ssl", "remove_ssl"}
 	for i := 0; i < len(updateParams); i++ {
 		param := updateParams[i]
 		if d.HasChange(param) && d.Get(param) != "" {
 			log.Printf("[INFO] Updating Incapsula site param (%s) with value (%s) for site_id: %s\n", param, d.Get(param).(string), d.Id())
-			_, err := client.UpdateSite(d.Id(), param, d.Get(param).(string))
+			_, err := client.UpdateSite(ctx, d.Id(), param, d.Get(param).(string), d.Get("account_id").(int))
 			if err != nil {
 				log.Printf("[ERROR] Could not update Incapsula site param (%s) with value (%s) for site_id: %s %s\n", param, d.Get(param).(string), d.Id(), err)
 				return err
@@ -550,10 +707,10 @@ func updateAdditionalSiteProperties(client *Client, d *schema.ResourceData) erro
 	return nil
 }
 
-func updateDataStorageRegion(client *Client, d *schema.ResourceData) error {
+func updateDataStorageRegion(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	if d.HasChange("data_storage_region") {
 		dataStorageRegion := d.Get("data_storage_region").(string)
-		_, err := client.UpdateDataStorageRegion(d.Id(), dataStorageRegion)
+		_, err := client.UpdateDataStorageRegion(ctx, d.Id(), dataStorageRegion, d.Get("account_id").(int))
 		if err != nil {
 			log.Printf("[ERROR] Could not set Incapsula site data storage region with value (%s) for site_id: %s %s\n", dataStorageRegion, d.Id(), err)
 			return err
@@ -562,12 +719,12 @@ func updateDataStorageRegion(client *Client, d *schema.ResourceData) error {
 	return nil
 }
 
-func updateMaskingSettings(client *Client, d *schema.ResourceData) error {
+func updateMaskingSettings(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	if d.HasChange("hashing_enabled") || d.HasChange("hash_salt") {
 		hashingEnabled := d.Get("hashing_enabled").(bool)
 		hashSalt := d.Get("hash_salt").(string)
 		maskingSettings := MaskingSettings{HashingEnabled: hashingEnabled, HashSalt: hashSalt}
-		err := client.UpdateMaskingSettings(d.Id(), &maskingSettings)
+		err := client.UpdateMaskingSettings(ctx, d.Id(), &maskingSettings, d.Get("account_id").(int))
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula site masking settings for site_id: %s %s\n", d.Id(), err)
 			return err
@@ -576,10 +733,10 @@ func updateMaskingSettings(client *Client, d *schema.ResourceData) error {
 	return nil
 }
 
-func updateLogLevel(client *Client, d *schema.ResourceData) error {
+func updateLogLevel(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	if d.HasChange("log_level") {
 		logLevel := d.Get("log_level").(string)
-		err := client.UpdateLogLevel(d.Id(), logLevel)
+		err := client.UpdateLogLevel(ctx, d.Id(), logLevel, d.Get("account_id").(int))
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula site log level: %s for site_id: %s %s\n", logLevel, d.Id(), err)
 			return err
@@ -588,7 +745,7 @@ func updateLogLevel(client *Client, d *schema.ResourceData) error {
 	return nil
 }
 
-func updatePerformanceSettings(client *Client, d *schema.ResourceData) error {
+func updatePerformanceSettings(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	if d.HasChange("perf_client_comply_no_cache") ||
 		d.HasChange("perf_client_enable_client_side_caching") ||
 		d.HasChange("perf_client_send_age_header") ||
@@ -633,7 +790,7 @@ func updatePerformanceSettings(client *Client, d *schema.ResourceData) error {
 		performanceSettings.TTL.PreferLastModified = d.Get("perf_ttl_prefer_last_modified").(bool)
 		performanceSettings.TTL.UseShortestCaching = d.Get("perf_ttl_use_shortest_caching").(bool)
 
-		_, err := client.UpdatePerformanceSettings(d.Id(), &performanceSettings)
+		_, err := client.UpdatePerformanceSettings(ctx, d.Id(), &performanceSettings, d.Get("account_id").(int))
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula performance settings for site_id: %s %s\n", d.Id(), err)
 			return err
@@ -641,3 +798,126 @@ func updatePerformanceSettings(client *Client, d *schema.ResourceData) error {
 	}
 	return nil
 }
+
+// Interval between two site status checks while waiting for a site
+var siteStatusPollInterval = 5 * time.Second
+
+// siteWaitTimeout returns the time left to wait for a site
+// The waiters of an operation share its deadline, so each one only gets the time the previous ones left
+func siteWaitTimeout(ctx context.Context, timeout time.Duration) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline); remaining < timeout {
+			return remaining
+		}
+	}
+	return timeout
+}
+
+// waitForSiteReady polls the site status until the API reports a status for the new site
+// Right after creation, the site may still be unknown to the API (res 9413)
+func waitForSiteReady(ctx context.Context, client *Client, domain string, siteID int, accountID int, timeout time.Duration) error {
+	log.Printf("[INFO] Waiting for Incapsula site %d (%s) to be ready for configuration\n", siteID, domain)
+
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{"pending"},
+		Target:       []string{"ready"},
+		Timeout:      siteWaitTimeout(ctx, timeout),
+		PollInterval: siteStatusPollInterval,
+		Refresh: func() (interface{}, string, error) {
+			siteStatusResponse, err := client.SiteStatus(ctx, domain, siteID, accountID)
+			if IsNotFound(err) {
+				log.Printf("[DEBUG] Incapsula site %d is not known yet: %s\n", siteID, err)
+				return siteID, "pending", nil
+			}
+			if err != nil {
+				return nil, "", err
+			}
+			if siteStatusResponse.Status == "" {
+				return siteStatusResponse, "pending", nil
+			}
+			return siteStatusResponse, "ready", nil
+		},
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("Error waiting for Incapsula site %d (%s) to be ready: %s", siteID, domain, err)
+	}
+
+	return nil
+}
+
+// Generated certificate validation statuses meaning the certificate has been issued
+var siteSSLValidatedStatuses = []string{"done", "ok"}
+
+// Arguments which may change the SSL validation or the status of the site when updated, and the wait arguments themselves
+var siteWaitArguments = []string{"wait_for_ssl_validation", "wait_for_status", "active", "approver", "domain_validation", "ignore_ssl", "remove_ssl"}
+
+// waitForSiteSettings waits for the SSL validation and the site status requested by wait_for_ssl_validation and wait_for_status
+func waitForSiteSettings(ctx context.Context, client *Client, d *schema.ResourceData, timeout time.Duration) error {
+	domain := d.Get("domain").(string)
+	siteID, _ := strconv.Atoi(d.Id())
+	accountID := d.Get("account_id").(int)
+
+	if d.Get("wait_for_ssl_validation").(bool) {
+		log.Printf("[INFO] Waiting for the generated certificate of Incapsula site %d (%s) to be validated\n", siteID, domain)
+		err := waitForSiteState(ctx, client, domain, siteID, accountID, timeout, func(siteStatusResponse *SiteStatusResponse) (bool, error) {
+			validationStatus := siteStatusResponse.Ssl.GeneratedCertificate.ValidationStatus
+			// Without a generated certificate (e.g. SSL isn't enabled on the site), there is nothing to wait for
+			if validationStatus == "" {
+				return false, fmt.Errorf("No generated certificate to validate, SSL may not be enabled on the site")
+			}
+			for _, validatedStatus := range siteSSLValidatedStatuses {
+				if validationStatus == validatedStatus {
+					return true, nil
+				}
+			}
+			log.Printf("[DEBUG] Incapsula site %d SSL validation status: %s\n", siteID, validationStatus)
+			return false, nil
+		})
+		if err != nil {
+			return fmt.Errorf("Error waiting for the SSL validation of Incapsula site %d (%s): %s", siteID, domain, err)
+		}
+	}
+
+	if status := d.Get("wait_for_status").(string); status != "" {
+		log.Printf("[INFO] Waiting for Incapsula site %d (%s) to reach status %s\n", siteID, domain, status)
+		err := waitForSiteState(ctx, client, domain, siteID, accountID, timeout, func(siteStatusResponse *SiteStatusResponse) (bool, error) {
+			log.Printf("[DEBUG] Incapsula site %d status: %s\n", siteID, siteStatusResponse.Status)
+			return siteStatusResponse.Status == status, nil
+		})
+		if err != nil {
+			return fmt.Errorf("Error waiting for Incapsula site %d (%s) to reach status %s: %s", siteID, domain, status, err)
+		}
+	}
+
+	return nil
+}
+
+// waitForSiteState polls the site status until the check reports that the site reached the expected state
+// An error from the check stops the wait, e.g. when the site can never reach the state
+func waitForSiteState(ctx context.Context, client *Client, domain string, siteID int, accountID int, timeout time.Duration, check func(*SiteStatusResponse) (bool, error)) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{"pending"},
+		Target:       []string{"done"},
+		Timeout:      siteWaitTimeout(ctx, timeout),
+		PollInterval: siteStatusPollInterval,
+		Refresh: func() (interface{}, string, error) {
+			siteStatusResponse, err := client.SiteStatus(ctx, domain, siteID, accountID)
+			if err != nil {
+				return nil, "", err
+			}
+			done, err := check(siteStatusResponse)
+			if err != nil {
+				return nil, "", err
+			}
+			if !done {
+				return siteStatusResponse, "pending", nil
+			}
+			return siteStatusResponse, "done", nil
+		},
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
@@ -0,0 +1,466 @@
+package incapsula
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceSiteCacheSettings() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSiteCacheSettingsCreate,
+		ReadContext:   resourceSiteCacheSettingsRead,
+		UpdateContext: resourceSiteCacheSettingsUpdate,
+		DeleteContext: resourceSiteCacheSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				idSlice, accountID, err := parseImportID(d.Id(), "site_id")
+				if err != nil {
+					return nil, err
+				}
+				d.Set("account_id", accountID)
+
+				siteID := idSlice[0]
+				d.Set("site_id", siteID)
+				d.SetId(siteID)
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			// Required Arguments
+			"site_id": {
+				Description: "Numeric identifier of the site to operate on.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			// Optional Arguments
+			"account_id": {
+				Description: "Numeric identifier of the account to operate on. If not specified, the provider account_id is used.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"mode": {
+				Description: "The caching mode of the site.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"level": {
+							Description: "Caching level. Options are `disable`, `standard`, `smart`, and `all_resources`.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+						},
+						"https": {
+							Description: "The resources that are cached over HTTPS, the general level applies. Options are `disabled`, `dont_include_html`, `include_html`, and `include_all_resources`.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+						},
+						"time": {
+							Description: "The time, in seconds, that you set for this option determines how often the cache is refreshed. Relevant for the `include_html` and `include_all_resources` levels only.",
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"key": {
+				Description: "The cache key settings of the site.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"unite_naked_full_cache": {
+							Description: "Use the Same Cache for Full and Naked Domains. For example, use the same cached resource for www.example.com/a and example.com/a.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+						},
+						"comply_vary": {
+							Description: "Comply with Vary. Cache resources in accordance with the Vary response header.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"response": {
+				Description: "The response caching settings of the site.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"stale_content": {
+							Description: "Serve stale content.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Computed:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"mode": {
+										Description: "The working mode for serving stale content. Options are `disabled`, `adaptive`, and `custom`.",
+										Type:        schema.TypeString,
+										Optional:    true,
+										Computed:    true,
+									},
+									"time": {
+										Description: "The time, in seconds, to serve stale content for when working in `custom` work mode.",
+										Type:        schema.TypeInt,
+										Optional:    true,
+										Computed:    true,
+									},
+								},
+							},
+						},
+						"cache_shield": {
+							Description: "Adds an intermediate cache between other Imperva PoPs and your origin servers to protect your servers from redundant requests.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+						},
+						"cache_response_header": {
+							Description: "Cache response headers.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Computed:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"mode": {
+										Description: "The working mode for caching response headers. Options are `all` and `custom`.",
+										Type:        schema.TypeString,
+										Optional:    true,
+										Computed:    true,
+									},
+									"headers": {
+										Description: "The response headers to be cached when working in `custom` mode. If empty, no response headers are cached.",
+										Type:        schema.TypeList,
+										Optional:    true,
+										Computed:    true,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+						"tag_response_header": {
+							Description: "Tag the response according to the value of this header. Specify which origin response header contains the cache tags in your resources.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+						},
+						"cache_empty_responses": {
+							Description: "Cache responses that don’t have a message body.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+						},
+						"cache_300x": {
+							Description: "When this option is checked Imperva will cache 301, 302, 303, 307, and 308 redirect response headers containing the target URI.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+						},
+						"cache_http_10_responses": {
+							Description: "Cache HTTP 1.0 type responses that don’t include the Content-Length header or chunking.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+						},
+						"cache_404": {
+							Description: "Cache 404 responses.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Computed:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"enabled": {
+										Description: "Whether or not to cache 404 responses.",
+										Type:        schema.TypeBool,
+										Optional:    true,
+										Computed:    true,
+									},
+									"time": {
+										Description: "The time in seconds to cache 404 responses.",
+										Type:        schema.TypeInt,
+										Optional:    true,
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"ttl": {
+				Description: "The TTL settings of the site.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"use_shortest_caching": {
+							Description: "Use shortest caching duration in case of conflicts. By default, the longest duration is used in case of conflict between caching rules or modes. When this option is checked, Imperva uses the shortest duration in case of conflict.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+						},
+						"prefer_last_modified": {
+							Description: "Prefer 'Last Modified' over eTag. When this option is checked, Imperva prefers using Last Modified values (if available) over eTag values (recommended on multi-server setups).",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"client_side": {
+				Description: "The client side caching settings of the site.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enable_client_side_caching": {
+							Description: "Cache content on client browsers or applications. When not enabled, content is cached only on the Imperva proxies.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+						},
+						"comply_no_cache": {
+							Description: "Comply with No-Cache and Max-Age directives in client requests. By default, these cache directives are ignored. Resources are dynamically profiled and re-configured to optimize performance.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+						},
+						"send_age_header": {
+							Description: "Send Cache-Control: max-age and Age headers.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceSiteCacheSettingsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	siteID := d.Get("site_id").(string)
+
+	// The settings always exist, the resource only takes them over
+	d.SetId(siteID)
+
+	diags := resourceSiteCacheSettingsUpdate(ctx, d, m)
+	if diags.HasError() {
+		d.SetId("")
+	}
+	return diags
+}
+
+func resourceSiteCacheSettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	log.Printf("[INFO] Reading Incapsula cache settings for site_id: %s\n", d.Id())
+
+	performanceSettings, err := client.GetPerformanceSettings(ctx, d.Id(), d.Get("account_id").(int))
+
+	// Site object may have been deleted
+	if IsNotFound(err) {
+		log.Printf("[INFO] Incapsula Site ID %s has already been deleted: %s\n", d.Id(), err)
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		log.Printf("[ERROR] Could not read Incapsula cache settings for site_id: %s, %s\n", d.Id(), err)
+		return diag.FromErr(err)
+	}
+
+	d.Set("site_id", d.Id())
+	d.Set("account_id", client.accountID(d.Get("account_id").(int)))
+
+	for key, value := range flattenSiteCacheSettings(performanceSettings) {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Printf("[INFO] Finished reading Incapsula cache settings for site_id: %s\n", d.Id())
+
+	return nil
+}
+
+func resourceSiteCacheSettingsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	// Start from the current settings so that the settings which aren't configured are kept as is
+	performanceSettings, err := client.GetPerformanceSettings(ctx, d.Id(), d.Get("account_id").(int))
+	if err != nil {
+		log.Printf("[ERROR] Could not read Incapsula cache settings for site_id: %s, %s\n", d.Id(), err)
+		return diag.FromErr(err)
+	}
+
+	expandSiteCacheSettings(d, performanceSettings)
+
+	log.Printf("[INFO] Updating Incapsula cache settings for site_id: %s\n", d.Id())
+
+	_, err = client.UpdatePerformanceSettings(ctx, d.Id(), performanceSettings, d.Get("account_id").(int))
+	if err != nil {
+		log.Printf("[ERROR] Could not update Incapsula cache settings for site_id: %s, %s\n", d.Id(), err)
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Updated Incapsula cache settings for site_id: %s\n", d.Id())
+
+	// Set the rest of the state from the resource read
+	return resourceSiteCacheSettingsRead(ctx, d, m)
+}
+
+func resourceSiteCacheSettingsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// The cache settings can't be deleted, they are left as is and only removed from the state
+	log.Printf("[INFO] Removing Incapsula cache settings for site_id: %s from the state, the settings are left unchanged\n", d.Id())
+
+	d.SetId("")
+
+	return nil
+}
+
+// expandSiteCacheSettings overrides the performance settings with the values set in the configuration
+func expandSiteCacheSettings(d *schema.ResourceData, performanceSettings *PerformanceSettings) {
+	setString := func(key string, target *string) {
+		if v, ok := d.GetOkExists(key); ok {
+			*target = v.(string)
+		}
+	}
+	setInt := func(key string, target *int) {
+		if v, ok := d.GetOkExists(key); ok {
+			*target = v.(int)
+		}
+	}
+	setBool := func(key string, target *bool) {
+		if v, ok := d.GetOkExists(key); ok {
+			*target = v.(bool)
+		}
+	}
+
+	setString("mode.0.level", &performanceSettings.Mode.Level)
+	setString("mode.0.https", &performanceSettings.Mode.HTTPS)
+	setInt("mode.0.time", &performanceSettings.Mode.Time)
+
+	setBool("key.0.unite_naked_full_cache", &performanceSettings.Key.UniteNakedFullCache)
+	setBool("key.0.comply_vary", &performanceSettings.Key.ComplyVary)
+
+	setString("response.0.stale_content.0.mode", &performanceSettings.Response.StaleContent.Mode)
+	setInt("response.0.stale_content.0.time", &performanceSettings.Response.StaleContent.Time)
+	setBool("response.0.cache_shield", &performanceSettings.Response.CacheShield)
+	setString("response.0.cache_response_header.0.mode", &performanceSettings.Response.CacheResponseHeader.Mode)
+	if v, ok := d.GetOkExists("response.0.cache_response_header.0.headers"); ok {
+		performanceSettings.Response.CacheResponseHeader.Headers = v.([]interface{})
+	}
+	setString("response.0.tag_response_header", &performanceSettings.Response.TagResponseHeader)
+	setBool("response.0.cache_empty_responses", &performanceSettings.Response.CacheEmptyResponses)
+	setBool("response.0.cache_300x", &performanceSettings.Response.Cache300X)
+	setBool("response.0.cache_http_10_responses", &performanceSettings.Response.CacheHTTP10Responses)
+	setBool("response.0.cache_404.0.enabled", &performanceSettings.Response.Cache404.Enabled)
+	setInt("response.0.cache_404.0.time", &performanceSettings.Response.Cache404.Time)
+
+	setBool("ttl.0.use_shortest_caching", &performanceSettings.TTL.UseShortestCaching)
+	setBool("ttl.0.prefer_last_modified", &performanceSettings.TTL.PreferLastModified)
+
+	setBool("client_side.0.enable_client_side_caching", &performanceSettings.ClientSide.EnableClientSideCaching)
+	setBool("client_side.0.comply_no_cache", &performanceSettings.ClientSide.ComplyNoCache)
+	setBool("client_side.0.send_age_header", &performanceSettings.ClientSide.SendAgeHeader)
+}
+
+// flattenSiteCacheSettings converts the performance settings to the blocks of the resource
+func flattenSiteCacheSettings(performanceSettings *PerformanceSettings) map[string]interface{} {
+	headers := performanceSettings.Response.CacheResponseHeader.Headers
+	if headers == nil {
+		headers = make([]interface{}, 0)
+	}
+
+	return map[string]interface{}{
+		"mode": []interface{}{
+			map[string]interface{}{
+				"level": performanceSettings.Mode.Level,
+				"https": performanceSettings.Mode.HTTPS,
+				"time":  performanceSettings.Mode.Time,
+			},
+		},
+		"key": []interface{}{
+			map[string]interface{}{
+				"unite_naked_full_cache": performanceSettings.Key.UniteNakedFullCache,
+				"comply_vary":            performanceSettings.Key.ComplyVary,
+			},
+		},
+		"response": []interface{}{
+			map[string]interface{}{
+				"stale_content": []interface{}{
+					map[string]interface{}{
+						"mode": performanceSettings.Response.StaleContent.Mode,
+						"time": performanceSettings.Response.StaleContent.Time,
+					},
+				},
+				"cache_shield": performanceSettings.Response.CacheShield,
+				"cache_response_header": []interface{}{
+					map[string]interface{}{
+						"mode":    performanceSettings.Response.CacheResponseHeader.Mode,
+						"headers": headers,
+					},
+				},
+				"tag_response_header":     performanceSettings.Response.TagResponseHeader,
+				"cache_empty_responses":   performanceSettings.Response.CacheEmptyResponses,
+				"cache_300x":              performanceSettings.Response.Cache300X,
+				"cache_http_10_responses": performanceSettings.Response.CacheHTTP10Responses,
+				"cache_404": []interface{}{
+					map[string]interface{}{
+						"enabled": performanceSettings.Response.Cache404.Enabled,
+						"time":    performanceSettings.Response.Cache404.Time,
+					},
+				},
+			},
+		},
+		"ttl": []interface{}{
+			map[string]interface{}{
+				"use_shortest_caching": performanceSettings.TTL.UseShortestCaching,
+				"prefer_last_modified": performanceSettings.TTL.PreferLastModified,
+			},
+		},
+		"client_side": []interface{}{
+			map[string]interface{}{
+				"enable_client_side_caching": performanceSettings.ClientSide.EnableClientSideCaching,
+				"comply_no_cache":            performanceSettings.ClientSide.ComplyNoCache,
+				"send_age_header":            performanceSettings.ClientSide.SendAgeHeader,
+			},
+		},
+	}
+}
@@ -0,0 +1,170 @@
+package incapsula
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const siteCacheSettingsResourceName = "incapsula_site_cache_settings.testacc-terraform-site-cache-settings"
+
+func TestAccIncapsulaSiteCacheSettings_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIncapsulaSiteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIncapsulaSiteCacheSettingsConfigBasic(),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckIncapsulaSiteCacheSettingsExists(siteCacheSettingsResourceName),
+					resource.TestCheckResourceAttr(siteCacheSettingsResourceName, "mode.0.level", "smart"),
+					resource.TestCheckResourceAttr(siteCacheSettingsResourceName, "response.0.cache_404.0.enabled", "true"),
+					resource.TestCheckResourceAttr(siteCacheSettingsResourceName, "response.0.cache_404.0.time", "60"),
+				),
+			},
+			{
+				ResourceName:      siteCacheSettingsResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckIncapsulaSiteCacheSettingsExists(name string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		res, ok := state.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Incapsula Site Cache Settings resource not found: %s", name)
+		}
+
+		siteID := res.Primary.ID
+		if siteID == "" {
+			return fmt.Errorf("Incapsula Site ID does not exist for Site Cache Settings")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		_, err := client.GetPerformanceSettings(context.Background(), siteID, 0)
+		if err != nil {
+			return fmt.Errorf("Incapsula Site Cache Settings: %s (site id: %s) does not exist", name, siteID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckIncapsulaSiteCacheSettingsConfigBasic() string {
+	return testAccCheckIncapsulaSiteConfigBasic(testAccDomain) + fmt.Sprintf(`
+resource "incapsula_site_cache_settings" "testacc-terraform-site-cache-settings" {
+  site_id = "${incapsula_site.testacc-terraform-site.id}"
+  mode {
+    level = "smart"
+  }
+  response {
+    cache_404 {
+      enabled = true
+      time    = 60
+    }
+  }
+  depends_on = ["%s"]
+}`, siteResourceName,
+	)
+}
+
+func TestResourceSiteCacheSettingsCreateKeepsUnconfiguredSettings(t *testing.T) {
+	current := `{"mode":{"level":"standard","https":"include_html","time":300},"key":{"comply_vary":true},"response":{"stale_content":{"mode":"adaptive"},"cache_shield":true,"cache_response_header":{"mode":"custom","headers":["X-Custom"]},"cache_404":{"enabled":false}},"ttl":{"prefer_last_modified":true},"client_side":{"send_age_header":true}}`
+
+	var updated PerformanceSettings
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/sites/42/settings/cache" {
+			t.Errorf("Should have have hit /sites/42/settings/cache endpoint. Got: %s", req.URL.String())
+		}
+		if req.Method == http.MethodPut {
+			body, _ := ioutil.ReadAll(req.Body)
+			if err := json.Unmarshal(body, &updated); err != nil {
+				t.Errorf("Should have sent the performance settings, got: %s", string(body))
+			}
+			rw.Write(body)
+			return
+		}
+		if updated.Mode.Level != "" {
+			json.NewEncoder(rw).Encode(&updated)
+			return
+		}
+		rw.Write([]byte(current))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURLRev2: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	d := schema.TestResourceDataRaw(t, resourceSiteCacheSettings().Schema, map[string]interface{}{
+		"site_id": "42",
+		"mode": []interface{}{
+			map[string]interface{}{"level": "smart"},
+		},
+		"response": []interface{}{
+			map[string]interface{}{
+				"cache_shield": false,
+				"cache_404": []interface{}{
+					map[string]interface{}{"enabled": true, "time": 60},
+				},
+			},
+		},
+	})
+
+	if diags := resourceSiteCacheSettingsCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("Should not have received an error, got: %+v", diags)
+	}
+
+	if updated.Mode.Level != "smart" || !updated.Response.Cache404.Enabled || updated.Response.Cache404.Time != 60 {
+		t.Errorf("Should have sent the configured settings, got: %+v", updated)
+	}
+	if updated.Response.CacheShield {
+		t.Errorf("Should have sent the explicitly disabled cache shield, got: %+v", updated.Response)
+	}
+	if updated.Mode.HTTPS != "include_html" || updated.Mode.Time != 300 || !updated.Key.ComplyVary || updated.Response.StaleContent.Mode != "adaptive" || !updated.TTL.PreferLastModified || !updated.ClientSide.SendAgeHeader {
+		t.Errorf("Should have kept the settings which aren't configured, got: %+v", updated)
+	}
+	if len(updated.Response.CacheResponseHeader.Headers) != 1 || updated.Response.CacheResponseHeader.Headers[0] != "X-Custom" {
+		t.Errorf("Should have kept the cached response headers, got: %+v", updated.Response.CacheResponseHeader)
+	}
+
+	if d.Id() != "42" || d.Get("mode.0.https").(string) != "include_html" || d.Get("response.0.cache_404.0.time").(int) != 60 {
+		t.Errorf("Should have set the state from the read, got ID %s and mode %+v", d.Id(), d.Get("mode"))
+	}
+}
+
+func TestResourceSiteCacheSettingsAccountID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if caid := req.URL.Query().Get("caid"); caid != "5678" {
+			t.Errorf("Should have sent the account_id as caid to %s %s, got: %s", req.Method, req.URL.Path, caid)
+		}
+		rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURLRev2: server.URL, AccountID: 1234}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	d := schema.TestResourceDataRaw(t, resourceSiteCacheSettings().Schema, map[string]interface{}{
+		"site_id":    "42",
+		"account_id": 5678,
+	})
+
+	if diags := resourceSiteCacheSettingsCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("Should not have received an error, got: %+v", diags)
+	}
+	if accountID := d.Get("account_id").(int); accountID != 5678 {
+		t.Errorf("Should have kept the account_id 5678, got: %d", accountID)
+	}
+}
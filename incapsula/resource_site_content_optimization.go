@@ -0,0 +1,230 @@
+package incapsula
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Content optimization settings, each one is an advanced performance setting with the same name
+var siteContentOptimizationSettings = []string{
+	"minify_javascript",
+	"minify_css",
+	"minify_static_html",
+	"compress_jpeg",
+	"compress_png",
+	"progressive_image_rendering",
+	"aggressive_compression",
+	"on_the_fly_compression",
+	"tcp_pre_pooling",
+}
+
+func resourceSiteContentOptimization() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSiteContentOptimizationCreate,
+		ReadContext:   resourceSiteContentOptimizationRead,
+		UpdateContext: resourceSiteContentOptimizationUpdate,
+		DeleteContext: resourceSiteContentOptimizationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				idSlice, accountID, err := parseImportID(d.Id(), "site_id")
+				if err != nil {
+					return nil, err
+				}
+				d.Set("account_id", accountID)
+
+				siteID := idSlice[0]
+				d.Set("site_id", siteID)
+				d.SetId(siteID)
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			// Required Arguments
+			"site_id": {
+				Description: "Numeric identifier of the site to operate on.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			// Optional Arguments
+			"account_id": {
+				Description: "Numeric identifier of the account to operate on. If not specified, the provider account_id is used.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"minify_javascript": {
+				Description: "Minify JavaScript files by removing whitespace and comments.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"minify_css": {
+				Description: "Minify CSS files by removing whitespace and comments.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"minify_static_html": {
+				Description: "Minify static HTML pages by removing whitespace and comments.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"compress_jpeg": {
+				Description: "Compress JPEG images by removing their metadata and reducing their quality.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"compress_png": {
+				Description: "Compress PNG images by removing their metadata.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"progressive_image_rendering": {
+				Description: "Render JPEG images progressively (requires compress_jpeg).",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"aggressive_compression": {
+				Description: "Compress JPEG images more aggressively, reducing their quality further (requires compress_jpeg).",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"on_the_fly_compression": {
+				Description: "Compress the resources which aren't compressed by the origin server (gzip).",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"tcp_pre_pooling": {
+				Description: "Keep pre-established TCP connections to the origin server.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceSiteContentOptimizationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	// The settings are settings of the site, so the site ID is the resource ID
+	d.SetId(d.Get("site_id").(string))
+
+	// Only the configured settings are changed, the others are read from the site
+	for _, param := range siteContentOptimizationSettings {
+		value, ok := d.GetOkExists(param)
+		if !ok {
+			continue
+		}
+		err := updateSiteContentOptimizationSetting(ctx, client, d.Id(), param, value.(bool), d.Get("account_id").(int))
+		if err != nil {
+			d.SetId("")
+			return diag.FromErr(err)
+		}
+	}
+
+	// Set the rest of the state from the resource read
+	return resourceSiteContentOptimizationRead(ctx, d, m)
+}
+
+func resourceSiteContentOptimizationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	siteID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("Error parsing site ID %s: %s", d.Id(), err))
+	}
+
+	log.Printf("[INFO] Reading Incapsula content optimization settings for site_id: %s\n", d.Id())
+
+	siteStatusResponse, err := client.SiteStatus(ctx, "content-optimization-read", siteID, d.Get("account_id").(int))
+
+	// Site object may have been deleted
+	if IsNotFound(err) {
+		log.Printf("[INFO] Incapsula Site ID %d has already been deleted: %s\n", siteID, err)
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		log.Printf("[ERROR] Could not read Incapsula content optimization settings for site_id: %s, %s\n", d.Id(), err)
+		return diag.FromErr(err)
+	}
+
+	performanceConfiguration := siteStatusResponse.PerformanceConfiguration
+
+	d.Set("site_id", d.Id())
+	d.Set("account_id", client.accountID(d.Get("account_id").(int)))
+	d.Set("minify_javascript", performanceConfiguration.MinifyJavascript)
+	d.Set("minify_css", performanceConfiguration.MinifyCSS)
+	d.Set("minify_static_html", performanceConfiguration.MinifyStaticHTML)
+	// Older API responses spell it compress_jepg
+	d.Set("compress_jpeg", performanceConfiguration.CompressJpeg || performanceConfiguration.CompressJepg)
+	d.Set("compress_png", performanceConfiguration.CompressPng)
+	d.Set("progressive_image_rendering", performanceConfiguration.ProgressiveImageRendering)
+	d.Set("aggressive_compression", performanceConfiguration.AggressiveCompression)
+	d.Set("on_the_fly_compression", performanceConfiguration.OnTheFlyCompression)
+	d.Set("tcp_pre_pooling", performanceConfiguration.TCPPrePooling)
+
+	log.Printf("[INFO] Finished reading Incapsula content optimization settings for site_id: %s\n", d.Id())
+
+	return nil
+}
+
+func resourceSiteContentOptimizationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	for _, param := range siteContentOptimizationSettings {
+		if !d.HasChange(param) {
+			continue
+		}
+		err := updateSiteContentOptimizationSetting(ctx, client, d.Id(), param, d.Get(param).(bool), d.Get("account_id").(int))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// Set the rest of the state from the resource read
+	return resourceSiteContentOptimizationRead(ctx, d, m)
+}
+
+func resourceSiteContentOptimizationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// The settings can't be deleted, they are left as is and only removed from the state
+	log.Printf("[INFO] Removing Incapsula content optimization settings for site_id: %s from the state, the settings are left unchanged\n", d.Id())
+
+	d.SetId("")
+
+	return nil
+}
+
+func updateSiteContentOptimizationSetting(ctx context.Context, client *Client, siteID, param string, value bool, accountID int) error {
+	err := client.UpdatePerformanceAdvancedSetting(ctx, siteID, param, strconv.FormatBool(value), accountID)
+	if err != nil {
+		log.Printf("[ERROR] Could not update Incapsula content optimization setting (%s) with value (%t) for site_id: %s %s\n", param, value, siteID, err)
+		return err
+	}
+	return nil
+}
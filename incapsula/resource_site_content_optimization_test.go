@@ -0,0 +1,163 @@
+package incapsula
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const siteContentOptimizationResourceName = "incapsula_site_content_optimization.testacc-terraform-site-content-optimization"
+
+func TestAccIncapsulaSiteContentOptimization_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIncapsulaSiteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIncapsulaSiteContentOptimizationConfigBasic(),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckIncapsulaSiteContentOptimizationExists(siteContentOptimizationResourceName),
+					resource.TestCheckResourceAttr(siteContentOptimizationResourceName, "minify_javascript", "true"),
+					resource.TestCheckResourceAttr(siteContentOptimizationResourceName, "compress_png", "false"),
+				),
+			},
+			{
+				ResourceName:      siteContentOptimizationResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckIncapsulaSiteContentOptimizationExists(name string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		res, ok := state.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Incapsula Site Content Optimization resource not found: %s", name)
+		}
+
+		siteID, err := strconv.Atoi(res.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Error parsing ID %v to int", res.Primary.ID)
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		siteStatusResponse, err := client.SiteStatus(context.Background(), "content-optimization-test", siteID, 0)
+		if err != nil {
+			return fmt.Errorf("Incapsula site %d does not exist: %s", siteID, err)
+		}
+		if !siteStatusResponse.PerformanceConfiguration.MinifyJavascript {
+			return fmt.Errorf("Incapsula Site Content Optimization: %s (site id: %d) was not applied", name, siteID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckIncapsulaSiteContentOptimizationConfigBasic() string {
+	return testAccCheckIncapsulaSiteConfigBasic(testAccDomain) + fmt.Sprintf(`
+resource "incapsula_site_content_optimization" "testacc-terraform-site-content-optimization" {
+  site_id           = "${incapsula_site.testacc-terraform-site.id}"
+  minify_javascript = true
+  compress_png      = false
+  depends_on        = ["%s"]
+}`, siteResourceName,
+	)
+}
+
+func TestResourceSiteContentOptimizationCreateOnlyConfiguredSettings(t *testing.T) {
+	updated := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/" + endpointPerformanceAdvanced:
+			updated[req.FormValue("param")] = req.FormValue("value")
+			rw.Write([]byte(`{"res":0,"res_message":"OK"}`))
+		case "/" + endpointSiteStatus:
+			rw.Write([]byte(`{"res":0,"site_id":42,"performance_configuration":{"minify_javascript":true,"compress_png":false,"compress_jepg":true,"tcp_pre_pooling":true}}`))
+		default:
+			t.Errorf("Should not have hit %s", req.URL.String())
+		}
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	d := schema.TestResourceDataRaw(t, resourceSiteContentOptimization().Schema, map[string]interface{}{
+		"site_id":           "42",
+		"minify_javascript": true,
+		"compress_png":      false,
+	})
+
+	if diags := resourceSiteContentOptimizationCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("Should not have received an error, got: %+v", diags)
+	}
+
+	if len(updated) != 2 || updated["minify_javascript"] != "true" || updated["compress_png"] != "false" {
+		t.Errorf("Should only have updated the configured settings, got: %+v", updated)
+	}
+	if d.Id() != "42" {
+		t.Errorf("Should have used the site ID as ID, got: %s", d.Id())
+	}
+	if !d.Get("compress_jpeg").(bool) || !d.Get("tcp_pre_pooling").(bool) || d.Get("minify_css").(bool) {
+		t.Errorf("Should have read the settings from the site status, got: %+v", d.State().Attributes)
+	}
+}
+
+func TestResourceSiteContentOptimizationReadDetectsDrift(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"res":0,"site_id":42,"performance_configuration":{"minify_javascript":false,"on_the_fly_compression":true}}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	d := schema.TestResourceDataRaw(t, resourceSiteContentOptimization().Schema, map[string]interface{}{
+		"site_id":           "42",
+		"minify_javascript": true,
+	})
+	d.SetId("42")
+
+	if diags := resourceSiteContentOptimizationRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("Should not have received an error, got: %+v", diags)
+	}
+	if d.Get("minify_javascript").(bool) || !d.Get("on_the_fly_compression").(bool) {
+		t.Errorf("Should have read the changed settings, got: %+v", d.State().Attributes)
+	}
+}
+
+func TestResourceSiteContentOptimizationAccountID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if accountID := req.FormValue("account_id"); accountID != "5678" {
+			t.Errorf("Should have sent the account_id to %s, got: %s", req.URL.Path, accountID)
+		}
+		rw.Write([]byte(`{"res":0,"site_id":42}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL, AccountID: 1234}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	d := schema.TestResourceDataRaw(t, resourceSiteContentOptimization().Schema, map[string]interface{}{
+		"site_id":    "42",
+		"account_id": 5678,
+		"minify_css": true,
+	})
+
+	if diags := resourceSiteContentOptimizationCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("Should not have received an error, got: %+v", diags)
+	}
+	if accountID := d.Get("account_id").(int); accountID != 5678 {
+		t.Errorf("Should have kept the account_id 5678, got: %d", accountID)
+	}
+}
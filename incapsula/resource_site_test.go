@@ -1,11 +1,17 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -65,7 +71,7 @@ func testAccCheckIncapsulaSiteDestroy(state *terraform.State) error {
 			return fmt.Errorf("Site ID conversion error for %s: %s", siteIDStr, err)
 		}
 
-		_, err = client.SiteStatus(testAccDomain, siteID)
+		_, err = client.SiteStatus(context.Background(), testAccDomain, siteID, 0)
 
 		if err == nil {
 			return fmt.Errorf("Incapsula site for domain: %s (site id: %d) still exists", testAccDomain, siteID)
@@ -93,7 +99,7 @@ func testCheckIncapsulaSiteExists(name string) resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		siteStatusResponse, err := client.SiteStatus(testAccDomain, siteID)
+		siteStatusResponse, err := client.SiteStatus(context.Background(), testAccDomain, siteID, 0)
 		if siteStatusResponse == nil {
 			return fmt.Errorf("Incapsula site for domain: %s (site id: %d) does not exist", testAccDomain, siteID)
 		}
@@ -110,3 +116,366 @@ func testAccCheckIncapsulaSiteConfigBasic(domain string) string {
 		domain,
 	)
 }
+
+func TestWaitForSiteReady(t *testing.T) {
+	defer func(interval time.Duration) { siteStatusPollInterval = interval }(siteStatusPollInterval)
+	siteStatusPollInterval = time.Millisecond
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.String() != fmt.Sprintf("/%s", endpointSiteStatus) {
+			t.Errorf("Should have have hit /%s endpoint. Got: %s", endpointSiteStatus, req.URL.String())
+		}
+		requests++
+
+		// The site is unknown for the first two checks
+		if requests <= 2 {
+			rw.Write([]byte(`{"res":9413,"res_message":"Unknown/unauthorized site_id"}`))
+			return
+		}
+		rw.Write([]byte(`{"res":0,"site_id":42,"status":"pending-dns-changes"}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	err := waitForSiteReady(context.Background(), client, testAccDomain, 42, 0, time.Minute)
+	if err != nil {
+		t.Errorf("Should not have received an error, got: %s", err)
+	}
+	if requests != 3 {
+		t.Errorf("Should have checked the site status 3 times, got: %d", requests)
+	}
+}
+
+func TestWaitForSiteReadyError(t *testing.T) {
+	defer func(interval time.Duration) { siteStatusPollInterval = interval }(siteStatusPollInterval)
+	siteStatusPollInterval = time.Millisecond
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"res":9411,"res_message":"Authentication parameters missing or incorrect"}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	err := waitForSiteReady(context.Background(), client, testAccDomain, 42, 0, time.Minute)
+	if err == nil || !strings.HasPrefix(err.Error(), "Error waiting for Incapsula site 42") {
+		t.Errorf("Should have received a wait error, got: %v", err)
+	}
+}
+
+func TestWaitForSiteReadyTimeout(t *testing.T) {
+	defer func(interval time.Duration) { siteStatusPollInterval = interval }(siteStatusPollInterval)
+	siteStatusPollInterval = time.Millisecond
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"res":9413,"res_message":"Unknown/unauthorized site_id"}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	err := waitForSiteReady(context.Background(), client, testAccDomain, 42, 0, 50*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "timeout") {
+		t.Errorf("Should have received a timeout error, got: %v", err)
+	}
+}
+
+func TestWaitForSiteSettings(t *testing.T) {
+	defer func(interval time.Duration) { siteStatusPollInterval = interval }(siteStatusPollInterval)
+	siteStatusPollInterval = time.Millisecond
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests++
+		if accountID := req.FormValue("account_id"); accountID != "5678" {
+			t.Errorf("Should have sent the site account_id, got: %s", accountID)
+		}
+
+		// The certificate is validated on the second check, the DNS cut-over on the fourth one
+		validationStatus := "pending_user_action"
+		if requests >= 2 {
+			validationStatus = "done"
+		}
+		status := "pending-dns-changes"
+		if requests >= 4 {
+			status = "fully_configured"
+		}
+		rw.Write([]byte(fmt.Sprintf(`{"res":0,"site_id":42,"status":"%s","ssl":{"generated_certificate":{"validation_status":"%s"}}}`, status, validationStatus)))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	d := schema.TestResourceDataRaw(t, resourceSite().Schema, map[string]interface{}{
+		"domain":                  testAccDomain,
+		"account_id":              5678,
+		"wait_for_ssl_validation": true,
+		"wait_for_status":         "fully_configured",
+	})
+	d.SetId("42")
+
+	err := waitForSiteSettings(context.Background(), client, d, time.Minute)
+	if err != nil {
+		t.Errorf("Should not have received an error, got: %s", err)
+	}
+	if requests != 4 {
+		t.Errorf("Should have checked the site status 4 times, got: %d", requests)
+	}
+}
+
+func TestWaitForSiteSettingsTimeout(t *testing.T) {
+	defer func(interval time.Duration) { siteStatusPollInterval = interval }(siteStatusPollInterval)
+	siteStatusPollInterval = time.Millisecond
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"res":0,"site_id":42,"status":"pending-dns-changes","ssl":{"generated_certificate":{"validation_status":"pending_user_action"}}}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	d := schema.TestResourceDataRaw(t, resourceSite().Schema, map[string]interface{}{
+		"domain":                  testAccDomain,
+		"wait_for_ssl_validation": true,
+	})
+	d.SetId("42")
+
+	err := waitForSiteSettings(context.Background(), client, d, 50*time.Millisecond)
+	if err == nil || !strings.HasPrefix(err.Error(), "Error waiting for the SSL validation of Incapsula site 42") {
+		t.Errorf("Should have received an SSL validation timeout error, got: %v", err)
+	}
+}
+
+func TestWaitForSiteSettingsNoGeneratedCertificate(t *testing.T) {
+	defer func(interval time.Duration) { siteStatusPollInterval = interval }(siteStatusPollInterval)
+	siteStatusPollInterval = time.Millisecond
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests++
+		rw.Write([]byte(`{"res":0,"site_id":42,"status":"fully_configured","ssl":{}}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	d := schema.TestResourceDataRaw(t, resourceSite().Schema, map[string]interface{}{
+		"domain":                  testAccDomain,
+		"wait_for_ssl_validation": true,
+	})
+	d.SetId("42")
+
+	err := waitForSiteSettings(context.Background(), client, d, time.Minute)
+	if err == nil || !strings.Contains(err.Error(), "No generated certificate to validate") {
+		t.Errorf("Should have received a no generated certificate error, got: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Should have stopped after the first site status, got: %d", requests)
+	}
+}
+
+func TestResourceSiteUpdateWaits(t *testing.T) {
+	defer func(interval time.Duration) { siteStatusPollInterval = interval }(siteStatusPollInterval)
+	siteStatusPollInterval = time.Millisecond
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/" + endpointSiteStatus:
+			requests++
+			rw.Write([]byte(`{"res":0,"site_id":42,"domain":"www.example.com","status":"fully_configured","ssl":{"generated_certificate":{"validation_status":"done"}}}`))
+		case "/" + endpointSiteUpdate:
+			rw.Write([]byte(`{"res":0,"site_id":42}`))
+		case "/" + endpointDataStorageRegionGet:
+			rw.Write([]byte(`{"res":0,"region":"US"}`))
+		default:
+			rw.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL, BaseURLRev2: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	state := &terraform.InstanceState{
+		ID: "42",
+		Attributes: map[string]string{
+			"domain":                  "www.example.com",
+			"wait_for_ssl_validation": "true",
+			"wait_for_status":         "fully_configured",
+		},
+	}
+
+	// Nothing changed, only the read checks the site status
+	d := resourceSite().Data(state)
+	if diags := resourceSiteUpdate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("Should not have received an error, got: %+v", diags)
+	}
+	if requests != 1 {
+		t.Errorf("Should not have waited for the unchanged site, got %d site status requests", requests)
+	}
+
+	// A new status to wait for is waited for, then read
+	requests = 0
+	d = schema.TestResourceDataRaw(t, resourceSite().Schema, map[string]interface{}{
+		"domain":          "www.example.com",
+		"wait_for_status": "fully_configured",
+	})
+	d.SetId("42")
+	if diags := resourceSiteUpdate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("Should not have received an error, got: %+v", diags)
+	}
+	if requests != 2 {
+		t.Errorf("Should have waited for the new status, got %d site status requests", requests)
+	}
+}
+
+func TestWaitForSiteSettingsContextDeadline(t *testing.T) {
+	defer func(interval time.Duration) { siteStatusPollInterval = interval }(siteStatusPollInterval)
+	siteStatusPollInterval = time.Millisecond
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"res":0,"site_id":42,"status":"pending-dns-changes","ssl":{"generated_certificate":{"validation_status":"pending_user_action"}}}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	d := schema.TestResourceDataRaw(t, resourceSite().Schema, map[string]interface{}{
+		"domain":                  testAccDomain,
+		"wait_for_ssl_validation": true,
+	})
+	d.SetId("42")
+
+	// The operation deadline is reached long before the timeout of the waiter
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := waitForSiteSettings(ctx, client, d, time.Minute)
+	if err == nil || !strings.HasPrefix(err.Error(), "Error waiting for the SSL validation of Incapsula site 42") {
+		t.Errorf("Should have received an SSL validation timeout error, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Should have stopped waiting at the context deadline, waited: %s", elapsed)
+	}
+}
+
+func TestSiteWaitTimeout(t *testing.T) {
+	if timeout := siteWaitTimeout(context.Background(), time.Minute); timeout != time.Minute {
+		t.Errorf("Should have used the timeout without a context deadline, got: %s", timeout)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if timeout := siteWaitTimeout(ctx, time.Minute); timeout > 10*time.Second || timeout <= 0 {
+		t.Errorf("Should have used the time left before the context deadline, got: %s", timeout)
+	}
+	if timeout := siteWaitTimeout(ctx, time.Second); timeout != time.Second {
+		t.Errorf("Should have used the timeout before the context deadline, got: %s", timeout)
+	}
+}
+
+func TestResourceSiteReadDNSRecords(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/" + endpointSiteStatus:
+			rw.Write([]byte(`{"res":0,"site_id":42,"domain":"www.example.com",
+				"dns":[{"dns_record_name":"www.example.com","set_type_to":"CNAME","set_data_to":["abc.x.incapdns.net"]},{"dns_record_name":"example.com","set_type_to":"A","set_data_to":["107.154.1.1","45.60.1.1"]}],
+				"original_dns":[{"dns_record_name":"www.example.com","set_type_to":"A","set_data_to":["1.2.3.4"]}],
+				"ssl":{"generated_certificate":{"validation_method":"dns","validation_status":"pending_user_action","san":["example.com","www.example.com"],
+				"validation_data":[{"dns_record_name":"example.com","set_type_to":"TXT","set_data_to":["globalsign-domain-verification=abc"]},{"dns_record_name":"www.example.com","set_type_to":"TXT","set_data_to":["globalsign-domain-verification=def"]}]}}}`))
+		case "/" + endpointDataStorageRegionGet:
+			rw.Write([]byte(`{"res":0,"region":"US"}`))
+		default:
+			rw.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL, BaseURLRev2: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	d := schema.TestResourceDataRaw(t, resourceSite().Schema, map[string]interface{}{"domain": "www.example.com"})
+	d.SetId("42")
+
+	if diags := resourceSiteRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("Should not have received an error, got: %+v", diags)
+	}
+
+	if d.Get("dns_records.#").(int) != 2 || d.Get("dns_records.1.type").(string) != "A" || d.Get("dns_records.1.values.1").(string) != "45.60.1.1" {
+		t.Errorf("Should have set all the DNS records, got: %+v", d.Get("dns_records"))
+	}
+	if d.Get("original_dns_records.#").(int) != 1 || d.Get("original_dns_records.0.values.0").(string) != "1.2.3.4" {
+		t.Errorf("Should have set the original DNS records, got: %+v", d.Get("original_dns_records"))
+	}
+	if d.Get("ssl_validation_records.#").(int) != 2 || d.Get("ssl_validation_records.1.values.0").(string) != "globalsign-domain-verification=def" {
+		t.Errorf("Should have set all the SSL validation records, got: %+v", d.Get("ssl_validation_records"))
+	}
+	if d.Get("domain_verification").(string) != "globalsign-domain-verification=abc" {
+		t.Errorf("Should have set the domain verification, got: %s", d.Get("domain_verification"))
+	}
+	if d.Get("ssl_san.#").(int) != 2 {
+		t.Errorf("Should have set the SANs, got: %+v", d.Get("ssl_san"))
+	}
+}
+
+func TestResourceSiteDeleteProtected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		t.Errorf("Should not have called the API, got: %s", req.URL.String())
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	d := schema.TestResourceDataRaw(t, resourceSite().Schema, map[string]interface{}{
+		"domain":              testAccDomain,
+		"deletion_protection": true,
+		"bypass_on_destroy":   true,
+	})
+	d.SetId("42")
+
+	diags := resourceSiteDelete(context.Background(), d, client)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "deletion_protection is enabled") {
+		t.Errorf("Should have received a deletion protection error, got: %+v", diags)
+	}
+	if d.Id() != "42" {
+		t.Errorf("Should have kept the site in the state, got ID: %s", d.Id())
+	}
+}
+
+func TestResourceSiteDeleteBypass(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/"+endpointSiteUpdate {
+			t.Errorf("Should have have hit /%s endpoint. Got: %s", endpointSiteUpdate, req.URL.String())
+		}
+		if req.FormValue("site_id") != "42" || req.FormValue("param") != "active" || req.FormValue("value") != "bypass" {
+			t.Errorf("Should have bypassed the site, got: %s", req.Form.Encode())
+		}
+		rw.Write([]byte(`{"res":0,"site_id":42}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	d := schema.TestResourceDataRaw(t, resourceSite().Schema, map[string]interface{}{
+		"domain":            testAccDomain,
+		"bypass_on_destroy": true,
+	})
+	d.SetId("42")
+
+	if diags := resourceSiteDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("Should not have received an error, got: %+v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("Should have removed the site from the state, got ID: %s", d.Id())
+	}
+}
@@ -1,11 +1,12 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -23,16 +24,17 @@ const botAccessControlChallengeSuspectedBotsDefaultAction = "false"
 
 func resourceWAFSecurityRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceWAFSecurityRuleCreate,
-		Read:   resourceWAFSecurityRuleRead,
-		Update: resourceWAFSecurityRuleUpdate,
-		Delete: resourceWAFSecurityRuleDelete,
+		CreateContext: resourceWAFSecurityRuleCreate,
+		ReadContext:   resourceWAFSecurityRuleRead,
+		UpdateContext: resourceWAFSecurityRuleUpdate,
+		DeleteContext: resourceWAFSecurityRuleDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				idSlice := strings.Split(d.Id(), "/")
-				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
-					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id/rule_id", d.Id())
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				idSlice, accountID, err := parseImportID(d.Id(), "site_id/rule_id")
+				if err != nil {
+					return nil, err
 				}
+				d.Set("account_id", accountID)
 
 				siteID, err := strconv.Atoi(idSlice[0])
 				ruleID := idSlice[1]
@@ -46,6 +48,13 @@ func resourceWAFSecurityRule() *schema.Resource {
 			},
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+
 		Schema: map[string]*schema.Schema{
 			// Required Arguments
 			"site_id": {
@@ -59,6 +68,15 @@ func resourceWAFSecurityRule() *schema.Resource {
 				Required:    true,
 			},
 
+			// Optional Arguments
+			"account_id": {
+				Description: "Numeric identifier of the account to operate on. If not specified, the provider account_id is used.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+
 			// Required for rule_id: api.threats.backdoor, api.threats.cross_site_scripting, api.threats.illegal_resource_access, api.threats.remote_file_inclusion, api.threats.sql_injection
 			"security_rule_action": {
 				Description: "The action that should be taken when a threat is detected, for example: api.threats.action.block_ip.",
@@ -93,7 +111,7 @@ func resourceWAFSecurityRule() *schema.Resource {
 	}
 }
 
-func resourceWAFSecurityRuleCreate(d *schema.ResourceData, m interface{}) error {
+func resourceWAFSecurityRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	ruleID := d.Get("rule_id").(string)
@@ -102,6 +120,7 @@ func resourceWAFSecurityRuleCreate(d *schema.ResourceData, m interface{}) error
 
 	if ruleID == backdoorRuleID || ruleID == crossSiteScriptingRuleID || ruleID == illegalResourceAccessRuleID || ruleID == remoteFileInclusionRuleID || ruleID == sqlInjectionRuleID {
 		_, err := client.ConfigureWAFSecurityRule(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			d.Get("security_rule_action").(string),
@@ -109,13 +128,15 @@ func resourceWAFSecurityRuleCreate(d *schema.ResourceData, m interface{}) error
 			"",
 			"",
 			"",
+			d.Get("account_id").(int),
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not create Incapsula WAF Rule rule_id (%s) and security_rule_action (%s) on site_id (%d), %s\n", ruleID, d.Get("security_rule_action").(string), d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	} else if ruleID == ddosRuleID {
 		_, err := client.ConfigureWAFSecurityRule(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -123,13 +144,15 @@ func resourceWAFSecurityRuleCreate(d *schema.ResourceData, m interface{}) error
 			d.Get("ddos_traffic_threshold").(string),
 			"",
 			"",
+			d.Get("account_id").(int),
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not create Incapsula WAF Rule rule_id (%s) with activation_mode (%s) and ddos_traffic_threshold (%s) on site_id (%d), %s\n", ruleID, d.Get("activation_mode").(string), d.Get("ddos_traffic_threshold").(string), d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	} else if ruleID == botAccessControlRuleID {
 		_, err := client.ConfigureWAFSecurityRule(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -137,10 +160,11 @@ func resourceWAFSecurityRuleCreate(d *schema.ResourceData, m interface{}) error
 			"",
 			d.Get("block_bad_bots").(string),
 			d.Get("challenge_suspected_bots").(string),
+			d.Get("account_id").(int),
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not create Incapsula WAF Rule rule_id (%s) with block_bad_bots (%s) and challenge_suspected_bots (%s) on site_id (%d), %s\n", ruleID, d.Get("block_bad_bots").(string), d.Get("challenge_suspected_bots").(string), d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -149,10 +173,10 @@ func resourceWAFSecurityRuleCreate(d *schema.ResourceData, m interface{}) error
 
 	log.Printf("[INFO] Created Incapsula WAF Rule rule_id (%s) on site_id (%d)\n", ruleID, d.Get("site_id").(int))
 
-	return resourceWAFSecurityRuleRead(d, m)
+	return resourceWAFSecurityRuleRead(ctx, d, m)
 }
 
-func resourceWAFSecurityRuleRead(d *schema.ResourceData, m interface{}) error {
+func resourceWAFSecurityRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by reading the SiteResponse for the site
 	client := m.(*Client)
 
@@ -160,10 +184,10 @@ func resourceWAFSecurityRuleRead(d *schema.ResourceData, m interface{}) error {
 
 	log.Printf("[INFO] Reading Incapsula WAF Rule for id: %s\n", ruleID)
 
-	siteStatusResponse, err := client.SiteStatus("waf-rule-read", d.Get("site_id").(int))
+	siteStatusResponse, err := client.SiteStatus(ctx, "waf-rule-read", d.Get("site_id").(int), d.Get("account_id").(int))
 
 	// Site object may have been deleted
-	if siteStatusResponse != nil && siteStatusResponse.Res.(float64) == 9413 {
+	if IsNotFound(err) {
 		log.Printf("[INFO] Incapsula Site ID %s has already been deleted: %s\n", d.Get("site_id"), err)
 		d.SetId("")
 		return nil
@@ -171,7 +195,7 @@ func resourceWAFSecurityRuleRead(d *schema.ResourceData, m interface{}) error {
 
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula WAF Rule for id: %s, %s\n", ruleID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	found := false
@@ -210,14 +234,16 @@ func resourceWAFSecurityRuleRead(d *schema.ResourceData, m interface{}) error {
 		return nil
 	}
 
+	d.Set("account_id", client.accountID(d.Get("account_id").(int)))
+
 	log.Printf("[INFO] Read Incapsula WAF Rule rule_id (%s) on site_id (%d)\n", ruleID, d.Get("site_id").(int))
 
 	return nil
 }
 
-func resourceWAFSecurityRuleUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceWAFSecurityRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// This is the same as create
-	return resourceWAFSecurityRuleCreate(d, m)
+	return resourceWAFSecurityRuleCreate(ctx, d, m)
 }
 
 func testAccStateWAFSecurityRuleID(s *terraform.State) (string, error) {
@@ -240,7 +266,7 @@ func testAccStateWAFSecurityRuleID(s *terraform.State) (string, error) {
 	return "", fmt.Errorf("Error finding site_id")
 }
 
-func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error {
+func resourceWAFSecurityRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	ruleID := d.Get("rule_id").(string)
@@ -251,6 +277,7 @@ func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 	switch ruleID {
 	case backdoorRuleID:
 		_, err := client.ConfigureWAFSecurityRule(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			backdoorRuleIDDefaultAction,
@@ -258,13 +285,15 @@ func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 			"",
 			"",
 			"",
+			d.Get("account_id").(int),
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not reset Incapsula WAF Rule rule_id (%s) with security_rule_action (%s) on site_id (%d) %s\n", ruleID, backdoorRuleIDDefaultAction, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case crossSiteScriptingRuleID:
 		_, err := client.ConfigureWAFSecurityRule(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			crossSiteScriptingRuleIDDefaultAction,
@@ -272,13 +301,15 @@ func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 			"",
 			"",
 			"",
+			d.Get("account_id").(int),
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not reset Incapsula WAF Rule rule_id (%s) with security_rule_action (%s) on site_id (%d) %s\n", ruleID, crossSiteScriptingRuleIDDefaultAction, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case illegalResourceAccessRuleID:
 		_, err := client.ConfigureWAFSecurityRule(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			illegalResourceAccessRuleIDDefaultAction,
@@ -286,13 +317,15 @@ func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 			"",
 			"",
 			"",
+			d.Get("account_id").(int),
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not reset Incapsula WAF Rule rule_id (%s) with security_rule_action (%s) on site_id (%d) %s\n", ruleID, illegalResourceAccessRuleIDDefaultAction, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case remoteFileInclusionRuleID:
 		_, err := client.ConfigureWAFSecurityRule(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			remoteFileInclusionRuleIDDefaultAction,
@@ -300,13 +333,15 @@ func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 			"",
 			"",
 			"",
+			d.Get("account_id").(int),
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not reset Incapsula WAF Rule rule_id (%s) with security_rule_action (%s) on site_id (%d) %s\n", ruleID, remoteFileInclusionRuleIDDefaultAction, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case sqlInjectionRuleID:
 		_, err := client.ConfigureWAFSecurityRule(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			sqlInjectionRuleIDDefaultAction,
@@ -314,13 +349,15 @@ func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 			"",
 			"",
 			"",
+			d.Get("account_id").(int),
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not reset Incapsula WAF Rule rule_id (%s) with security_rule_action (%s) on site_id (%d) %s\n", ruleID, sqlInjectionRuleIDDefaultAction, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case ddosRuleID:
 		_, err := client.ConfigureWAFSecurityRule(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -328,13 +365,15 @@ func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 			ddosRuleIDDefaultDDOSTrafficThreshold,
 			"",
 			"",
+			d.Get("account_id").(int),
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not reset Incapsula WAF Rule rule_id (%s) with default_activation_mode (%s) and ddos_traffic_threshold (%s) on site_id (%d) %s\n", ruleID, ddosRuleIDDefaultActivationMode, ddosRuleIDDefaultDDOSTrafficThreshold, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case botAccessControlRuleID:
 		_, err := client.ConfigureWAFSecurityRule(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -342,10 +381,11 @@ func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 			"",
 			botAccessControlBlockBadBotsDefaultAction,
 			botAccessControlChallengeSuspectedBotsDefaultAction,
+			d.Get("account_id").(int),
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not reset Incapsula WAF Rule rule_id (%s) with block_bad_bots (%s) and challenge_suspected_bots (%s) on site_id (%d) %s\n", ruleID, botAccessControlBlockBadBotsDefaultAction, botAccessControlChallengeSuspectedBotsDefaultAction, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	}
 